@@ -0,0 +1,103 @@
+package main
+
+import (
+  "btaskee-quiz/importer"
+  "btaskee-quiz/models"
+  "btaskee-quiz/services"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "os"
+  "path/filepath"
+  "strings"
+)
+
+// runImportCommand parses a quiz file and prints the questions as a create-quiz request.
+// With -save the quiz is created directly in Redis.
+//
+// Usage: go run . import [-format csv|json|gift|aiken] [-title "Quiz title"] [-save] <file>
+func runImportCommand(args []string) int {
+  flags := flag.NewFlagSet("import", flag.ContinueOnError)
+  format := flags.String("format", "", "file format: csv, json, gift or aiken (default: detected from extension)")
+  title := flags.String("title", "", "quiz title (default: title from the file, or the file name)")
+  save := flags.Bool("save", false, "create the quiz in Redis instead of printing it")
+  if err := flags.Parse(args); err != nil {
+    return 2
+  }
+
+  if flags.NArg() != 1 {
+    fmt.Fprintln(os.Stderr, "usage: import [-format csv|json|gift|aiken] [-title title] [-save] <file>")
+    return 2
+  }
+  path := flags.Arg(0)
+
+  importFormat := importer.Format(strings.ToLower(*format))
+  if importFormat == "" {
+    detected, err := importer.DetectFormat(path)
+    if err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return 2
+    }
+    importFormat = detected
+  }
+
+  data, err := os.ReadFile(path)
+  if err != nil {
+    fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", path, err)
+    return 1
+  }
+
+  result, err := importer.Parse(importFormat, data)
+  if err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    return 1
+  }
+
+  for _, importErr := range result.Errors {
+    fmt.Fprintf(os.Stderr, "%s: %s\n", path, importErr.Error())
+  }
+  fmt.Fprintf(os.Stderr, "%d questions imported, %d errors\n", len(result.Questions), len(result.Errors))
+
+  if len(result.Questions) == 0 {
+    return 1
+  }
+
+  request := models.CreateQuizRequest{
+    Title:     *title,
+    Questions: result.Questions,
+    Rounds:    result.Rounds,
+    Settings:  result.Settings,
+  }
+  if request.Title == "" {
+    request.Title = result.Title
+  }
+  if request.Title == "" {
+    request.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+  }
+
+  if !*save {
+    output, err := json.MarshalIndent(request, "", "  ")
+    if err != nil {
+      fmt.Fprintln(os.Stderr, err)
+      return 1
+    }
+    fmt.Println(string(output))
+    return 0
+  }
+
+  redisService := services.NewRedisService()
+  defer redisService.Close()
+  if !redisService.IsAvailable() {
+    fmt.Fprintln(os.Stderr, "Redis is not available, cannot save quiz")
+    return 1
+  }
+
+  quiz, err := services.NewStandaloneQuizService(redisService).CreateQuiz(request)
+  if err != nil {
+    fmt.Fprintln(os.Stderr, err)
+    return 1
+  }
+
+  fmt.Printf("Created quiz %s (%s)\n", quiz.Title, quiz.ID)
+  return 0
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// GetArchivedQuizzes lists ended quizzes, most recently ended first (?offset=&limit=)
+// APi /api/v1/quizzes/archived [GET]
+func (h *HTTPHandler) GetArchivedQuizzes(c *gin.Context) {
+  offset, limit, ok := pagination(c)
+  if !ok {
+    return
+  }
+
+  archived, total, err := h.quizService.ListArchivedQuizzes(offset, limit)
+  if err != nil {
+    c.JSON(http.StatusInternalServerError, gin.H{
+      "error": "Failed to get archived quizzes: " + err.Error(),
+    })
+    return
+  }
+
+  quizzes := make([]interface{}, 0, len(archived))
+  for _, quiz := range archived {
+    quizzes = append(quizzes, quiz.ViewFor(h.isHost(c, quiz), nil))
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "quizzes": quizzes,
+    "count":   len(quizzes),
+    "total":   total,
+    "offset":  offset,
+    "limit":   limit,
+  })
+}
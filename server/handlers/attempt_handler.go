@@ -0,0 +1,56 @@
+package handlers
+
+import (
+  "btaskee-quiz/models"
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// StartAttempt starts the participant's personal clock in a self-paced quiz (X-User-ID)
+// APi /api/v1/quizzes/:id/attempt/start [POST]
+func (h *HTTPHandler) StartAttempt(c *gin.Context) {
+  h.attemptAction(c, "start attempt", h.quizService.StartAttempt)
+}
+
+// FinishAttempt ends the participant's attempt early (X-User-ID)
+// APi /api/v1/quizzes/:id/attempt/finish [POST]
+func (h *HTTPHandler) FinishAttempt(c *gin.Context) {
+  h.attemptAction(c, "finish attempt", h.quizService.FinishAttempt)
+}
+
+// GetAttempt returns the participant's progress and current question (X-User-ID)
+// APi /api/v1/quizzes/:id/attempt [GET]
+func (h *HTTPHandler) GetAttempt(c *gin.Context) {
+  h.attemptAction(c, "get attempt", h.quizService.GetAttempt)
+}
+
+func (h *HTTPHandler) attemptAction(c *gin.Context, action string, fn func(quizID, userID string) (models.AttemptState, error)) {
+  quiz, err := h.quizService.GetQuiz(c.Param("id"))
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Quiz not found",
+    })
+    return
+  }
+
+  user := h.participant(c, quiz)
+  if user == nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "A participant X-User-ID is required",
+    })
+    return
+  }
+
+  attempt, err := fn(quiz.ID, user.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to " + action + ": " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "attempt": attempt,
+  })
+}
@@ -3,7 +3,9 @@ package handlers
 import (
   "btaskee-quiz/models"
   "btaskee-quiz/services"
+  "errors"
   "net/http"
+  "strconv"
 
   "github.com/gin-gonic/gin"
 )
@@ -23,20 +25,34 @@ func NewHTTPHandler(quizService *services.QuizService) *HTTPHandler {
 // CreateQuiz creates a new quiz
 // APi /api/v1/quizzes [POST]
 func (h *HTTPHandler) CreateQuiz(c *gin.Context) {
-  var request struct {
-    Title string `json:"title" binding:"required"`
-  }
+  var request models.CreateQuizRequest
 
-  if err := c.ShouldBindJSON(&request); err != nil {
+  if err := c.ShouldBindJSON(&request); err != nil || request.Title == "" {
     c.JSON(http.StatusBadRequest, gin.H{
       "error": "Title is required",
     })
     return
   }
 
-  quiz, err := h.quizService.CreateQuiz(request.Title)
+  if len(request.Questions) == 0 && len(request.FromBank) == 0 && !request.UseSampleQuestions {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Questions or from_bank are required unless use_sample_questions is set",
+    })
+    return
+  }
+
+  if len(request.Questions) > 0 {
+    if err := models.ValidateQuestions(request.Questions); err != nil {
+      c.JSON(http.StatusBadRequest, gin.H{
+        "error": "Invalid questions: " + err.Error(),
+      })
+      return
+    }
+  }
+
+  quiz, err := h.quizService.CreateQuiz(request)
   if err != nil {
-    c.JSON(http.StatusInternalServerError, gin.H{
+    c.JSON(http.StatusBadRequest, gin.H{
       "error": "Failed to create quiz: " + err.Error(),
     })
     return
@@ -68,7 +84,7 @@ func (h *HTTPHandler) GetQuiz(c *gin.Context) {
   }
 
   c.JSON(http.StatusOK, gin.H{
-    "quiz": quiz,
+    "quiz": quiz.ViewFor(h.isHost(c, quiz), h.participant(c, quiz)),
   })
 }
 
@@ -85,7 +101,7 @@ func (h *HTTPHandler) JoinQuiz(c *gin.Context) {
 
   user, err := h.quizService.JoinQuiz(request.QuizID, request.Name)
   if err != nil {
-    c.JSON(http.StatusBadRequest, gin.H{
+    c.JSON(errorStatus(err), gin.H{
       "error": "Failed to join quiz: " + err.Error(),
     })
     return
@@ -123,7 +139,7 @@ func (h *HTTPHandler) SubmitAnswer(c *gin.Context) {
 
   err := h.quizService.SubmitAnswer(request.QuizID, userID, request.QuestionID, request.Answer)
   if err != nil {
-    c.JSON(http.StatusBadRequest, gin.H{
+    c.JSON(errorStatus(err), gin.H{
       "error": "Failed to submit answer: " + err.Error(),
     })
     return
@@ -134,7 +150,7 @@ func (h *HTTPHandler) SubmitAnswer(c *gin.Context) {
   })
 }
 
-// GetLeaderboard retrieves the leaderboard for a quiz
+// GetLeaderboard retrieves a page of the leaderboard for a quiz (?offset=&limit=)
 func (h *HTTPHandler) GetLeaderboard(c *gin.Context) {
   quizID := c.Param("id")
   if quizID == "" {
@@ -144,7 +160,12 @@ func (h *HTTPHandler) GetLeaderboard(c *gin.Context) {
     return
   }
 
-  leaderboard, err := h.quizService.GetLeaderboard(quizID)
+  offset, limit, ok := pagination(c)
+  if !ok {
+    return
+  }
+
+  leaderboard, total, err := h.quizService.GetLeaderboardPage(quizID, offset, limit)
   if err != nil {
     c.JSON(http.StatusNotFound, gin.H{
       "error": "Failed to get leaderboard: " + err.Error(),
@@ -154,22 +175,53 @@ func (h *HTTPHandler) GetLeaderboard(c *gin.Context) {
 
   c.JSON(http.StatusOK, gin.H{
     "leaderboard": leaderboard,
+    "count":       len(leaderboard),
+    "total":       total,
+    "offset":      offset,
+    "limit":       limit,
   })
 }
 
-// StartQuiz starts a quiz session
-func (h *HTTPHandler) StartQuiz(c *gin.Context) {
+// GetLeaderboardAround returns a user's leaderboard entry and the entries either side of it (?radius=)
+// APi /api/v1/quizzes/:id/leaderboard/around/:userId [GET]
+func (h *HTTPHandler) GetLeaderboardAround(c *gin.Context) {
   quizID := c.Param("id")
-  if quizID == "" {
+  userID := c.Param("userId")
+
+  radius, err := strconv.Atoi(c.DefaultQuery("radius", strconv.Itoa(defaultLeaderboardRadius)))
+  if err != nil || radius < 0 || radius > maxLeaderboardRadius {
     c.JSON(http.StatusBadRequest, gin.H{
-      "error": "Quiz ID is required",
+      "error": "radius must be between 0 and " + strconv.Itoa(maxLeaderboardRadius),
     })
     return
   }
 
-  err := h.quizService.StartQuiz(quizID)
+  leaderboard, total, err := h.quizService.GetLeaderboardAround(quizID, userID, radius)
   if err != nil {
-    c.JSON(http.StatusBadRequest, gin.H{
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Failed to get leaderboard: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "leaderboard": leaderboard,
+    "count":       len(leaderboard),
+    "total":       total,
+    "user_id":     userID,
+  })
+}
+
+// StartQuiz starts a quiz session (host only)
+func (h *HTTPHandler) StartQuiz(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.StartQuiz(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
       "error": "Failed to start quiz: " + err.Error(),
     })
     return
@@ -180,19 +232,16 @@ func (h *HTTPHandler) StartQuiz(c *gin.Context) {
   })
 }
 
-// EndQuiz ends a quiz session
+// EndQuiz ends a quiz session (host only)
 func (h *HTTPHandler) EndQuiz(c *gin.Context) {
-  quizID := c.Param("id")
-  if quizID == "" {
-    c.JSON(http.StatusBadRequest, gin.H{
-      "error": "Quiz ID is required",
-    })
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
     return
   }
 
-  err := h.quizService.EndQuiz(quizID)
+  err := h.quizService.EndQuiz(quiz.ID)
   if err != nil {
-    c.JSON(http.StatusBadRequest, gin.H{
+    c.JSON(errorStatus(err), gin.H{
       "error": "Failed to end quiz: " + err.Error(),
     })
     return
@@ -203,6 +252,27 @@ func (h *HTTPHandler) EndQuiz(c *gin.Context) {
   })
 }
 
+// CloseQuestion closes a question and scores deferred (closest-wins) answers (host only)
+// APi /api/v1/quizzes/:id/questions/:questionId/close [POST]
+func (h *HTTPHandler) CloseQuestion(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.CloseQuestion(quiz.ID, c.Param("questionId"))
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to close question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Question closed successfully",
+  })
+}
+
 //// GetUser retrieves user information
 //func (h *HTTPHandler) GetUser(c *gin.Context) {
 //  userID := c.Param("id")
@@ -226,6 +296,87 @@ func (h *HTTPHandler) EndQuiz(c *gin.Context) {
 //  })
 //}
 
+// errorStatus maps a service error to an HTTP status: lifecycle and lobby violations are conflicts
+func errorStatus(err error) int {
+  var stateErr *services.QuizStateError
+  if errors.As(err, &stateErr) {
+    return http.StatusConflict
+  }
+  if errors.Is(err, models.ErrLobbyLocked) || errors.Is(err, models.ErrQuizFull) || errors.Is(err, models.ErrLateJoinDenied) {
+    return http.StatusConflict
+  }
+  return http.StatusBadRequest
+}
+
+// isHost checks the X-Host-Token header (or host_token query parameter) against the quiz
+func (h *HTTPHandler) isHost(c *gin.Context, quiz *models.Quiz) bool {
+  token := c.GetHeader("X-Host-Token")
+  if token == "" {
+    token = c.Query("host_token")
+  }
+  return h.quizService.IsHost(quiz, token)
+}
+
+// isAdmin checks the X-Admin-Token header (or admin_token query parameter) against ADMIN_TOKEN
+func (h *HTTPHandler) isAdmin(c *gin.Context) bool {
+  token := c.GetHeader("X-Admin-Token")
+  if token == "" {
+    token = c.Query("admin_token")
+  }
+  return h.quizService.IsAdmin(token)
+}
+
+// requireAdmin answers 403 unless the request carries the admin token
+func (h *HTTPHandler) requireAdmin(c *gin.Context) bool {
+  if !h.isAdmin(c) {
+    c.JSON(http.StatusForbidden, gin.H{
+      "error": "Admin token is required",
+    })
+    return false
+  }
+  return true
+}
+
+// Page sizes accepted by list endpoints
+const (
+  defaultPageLimit = 20
+  maxPageLimit     = 100
+
+  // Entries shown either side of a user on their part of the leaderboard
+  defaultLeaderboardRadius = 5
+  maxLeaderboardRadius     = 50
+)
+
+// pagination reads the offset and limit query parameters, answering 400 if they are invalid
+func pagination(c *gin.Context) (int, int, bool) {
+  offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+  if err != nil || offset < 0 {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "offset must be a non-negative integer",
+    })
+    return 0, 0, false
+  }
+
+  limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultPageLimit)))
+  if err != nil || limit < 1 || limit > maxPageLimit {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "limit must be between 1 and " + strconv.Itoa(maxPageLimit),
+    })
+    return 0, 0, false
+  }
+
+  return offset, limit, true
+}
+
+// participant returns the quiz participant identified by the X-User-ID header (or user_id query parameter)
+func (h *HTTPHandler) participant(c *gin.Context, quiz *models.Quiz) *models.User {
+  userID := c.GetHeader("X-User-ID")
+  if userID == "" {
+    userID = c.Query("user_id")
+  }
+  return quiz.GetParticipants()[userID]
+}
+
 // HealthCheck provides health check endpoint
 func (h *HTTPHandler) HealthCheck(c *gin.Context) {
   redisStatus := "connected"
@@ -233,11 +384,15 @@ func (h *HTTPHandler) HealthCheck(c *gin.Context) {
     redisStatus = "disconnected"
   }
 
+  h.quizService.Mu.RLock()
+  quizzes, clients := len(h.quizService.Quizzes), len(h.quizService.Clients)
+  h.quizService.Mu.RUnlock()
+
   c.JSON(http.StatusOK, gin.H{
     "status":  "healthy",
     "redis":   redisStatus,
-    "quizzes": len(h.quizService.Quizzes),
-    "clients": len(h.quizService.Clients),
+    "quizzes": quizzes,
+    "clients": clients,
   })
 }
 
@@ -251,13 +406,16 @@ func (h *HTTPHandler) GetActiveQuizzes(c *gin.Context) {
     return
   }
 
-  quizzes := make([]*models.Quiz, 0)
+  quizzes := make([]interface{}, 0)
   for _, quizID := range activeQuizzes {
     quiz, err := h.quizService.GetQuiz(quizID)
     if err != nil {
       continue // Skip if quiz can't be loaded
     }
-    quizzes = append(quizzes, quiz)
+    if quiz.GetStatus() == models.QuizStatusEnded {
+      continue // Archived by another instance
+    }
+    quizzes = append(quizzes, quiz.ViewFor(h.isHost(c, quiz), nil))
   }
 
   c.JSON(http.StatusOK, gin.H{
@@ -266,23 +424,14 @@ func (h *HTTPHandler) GetActiveQuizzes(c *gin.Context) {
   })
 }
 
-// DeleteQuiz deletes a quiz
+// DeleteQuiz deletes a quiz (host only)
 func (h *HTTPHandler) DeleteQuiz(c *gin.Context) {
-  quizID := c.Param("id")
-  if quizID == "" {
-    c.JSON(http.StatusBadRequest, gin.H{
-      "error": "Quiz ID is required",
-    })
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
     return
   }
 
-  // Remove from memory
-  h.quizService.Mu.Lock()
-  delete(h.quizService.Quizzes, quizID)
-  h.quizService.Mu.Unlock()
-
-  // Remove from Redis
-  err := h.quizService.RedisService.DeleteQuiz(quizID)
+  err := h.quizService.DeleteQuiz(quiz.ID)
   if err != nil {
     c.JSON(http.StatusInternalServerError, gin.H{
       "error": "Failed to delete quiz: " + err.Error(),
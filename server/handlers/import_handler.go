@@ -0,0 +1,116 @@
+package handlers
+
+import (
+  "btaskee-quiz/importer"
+  "btaskee-quiz/models"
+  "io"
+  "net/http"
+  "path/filepath"
+  "strings"
+
+  "github.com/gin-gonic/gin"
+)
+
+// maxImportSize limits the size of uploaded quiz files
+const maxImportSize = 5 << 20
+
+// ImportQuiz creates a quiz from an uploaded CSV, JSON, GIFT or Aiken file.
+// Form fields: file (required), format (optional, detected from the extension),
+// title (optional) and dry_run (parse and report without creating a quiz).
+// APi /api/v1/quizzes/import [POST]
+func (h *HTTPHandler) ImportQuiz(c *gin.Context) {
+  fileHeader, err := c.FormFile("file")
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "File is required",
+    })
+    return
+  }
+
+  if fileHeader.Size > maxImportSize {
+    c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+      "error": "File is too large",
+    })
+    return
+  }
+
+  format := importer.Format(strings.ToLower(c.PostForm("format")))
+  if format == "" {
+    format, err = importer.DetectFormat(fileHeader.Filename)
+    if err != nil {
+      c.JSON(http.StatusBadRequest, gin.H{
+        "error": err.Error(),
+      })
+      return
+    }
+  }
+
+  file, err := fileHeader.Open()
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to read file: " + err.Error(),
+    })
+    return
+  }
+  defer file.Close()
+
+  data, err := io.ReadAll(io.LimitReader(file, maxImportSize))
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to read file: " + err.Error(),
+    })
+    return
+  }
+
+  result, err := importer.Parse(format, data)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": err.Error(),
+    })
+    return
+  }
+
+  if c.PostForm("dry_run") == "true" {
+    c.JSON(http.StatusOK, gin.H{
+      "result": result,
+    })
+    return
+  }
+
+  if len(result.Questions) == 0 {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error":  "No valid questions found",
+      "errors": result.Errors,
+    })
+    return
+  }
+
+  title := c.PostForm("title")
+  if title == "" {
+    title = result.Title
+  }
+  if title == "" {
+    title = strings.TrimSuffix(filepath.Base(fileHeader.Filename), filepath.Ext(fileHeader.Filename))
+  }
+
+  quiz, err := h.quizService.CreateQuiz(models.CreateQuizRequest{
+    Title:     title,
+    Questions: result.Questions,
+    Rounds:    result.Rounds,
+    Settings:  result.Settings,
+  })
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error":  "Failed to create quiz: " + err.Error(),
+      "errors": result.Errors,
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message":  "Quiz imported successfully",
+    "quiz":     quiz,
+    "imported": len(result.Questions),
+    "errors":   result.Errors,
+  })
+}
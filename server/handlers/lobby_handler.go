@@ -0,0 +1,42 @@
+package handlers
+
+import (
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// LockQuiz locks the lobby so that nobody else can join (host only)
+// APi /api/v1/quizzes/:id/lock [POST]
+func (h *HTTPHandler) LockQuiz(c *gin.Context) {
+  h.setLobbyLocked(c, true)
+}
+
+// UnlockQuiz reopens the lobby (host only)
+// APi /api/v1/quizzes/:id/unlock [POST]
+func (h *HTTPHandler) UnlockQuiz(c *gin.Context) {
+  h.setLobbyLocked(c, false)
+}
+
+func (h *HTTPHandler) setLobbyLocked(c *gin.Context, locked bool) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.SetLobbyLocked(quiz.ID, locked)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to change lobby lock: " + err.Error(),
+    })
+    return
+  }
+
+  message := "Lobby unlocked successfully"
+  if locked {
+    message = "Lobby locked successfully"
+  }
+  c.JSON(http.StatusOK, gin.H{
+    "message": message,
+  })
+}
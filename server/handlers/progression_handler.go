@@ -0,0 +1,148 @@
+package handlers
+
+import (
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// NextQuestion closes the current question and shows the next one (host only)
+// APi /api/v1/quizzes/:id/next [POST]
+func (h *HTTPHandler) NextQuestion(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  question, err := h.quizService.NextQuestion(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to move to next question: " + err.Error(),
+    })
+    return
+  }
+
+  if question == nil {
+    c.JSON(http.StatusOK, gin.H{
+      "message": "Round ended",
+      "round":   quiz.CurrentRound(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message":        "Moved to next question",
+    "question":       question,
+    "question_index": quiz.CurrentQuestionIndex(),
+  })
+}
+
+// PreviousQuestion closes the current question and shows the previous one again (host only)
+// APi /api/v1/quizzes/:id/previous [POST]
+func (h *HTTPHandler) PreviousQuestion(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  question, err := h.quizService.PreviousQuestion(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to move to previous question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message":        "Moved to previous question",
+    "question":       question,
+    "question_index": quiz.CurrentQuestionIndex(),
+  })
+}
+
+// PauseQuiz pauses a running quiz (host only)
+// APi /api/v1/quizzes/:id/pause [POST]
+func (h *HTTPHandler) PauseQuiz(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.PauseQuiz(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to pause quiz: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Quiz paused successfully",
+  })
+}
+
+// ResumeQuiz resumes a paused quiz (host only)
+// APi /api/v1/quizzes/:id/resume [POST]
+func (h *HTTPHandler) ResumeQuiz(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.ResumeQuiz(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to resume quiz: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Quiz resumed successfully",
+  })
+}
+
+// ResetQuiz takes an ended quiz back to waiting with scores cleared (host only)
+// APi /api/v1/quizzes/:id/reset [POST]
+func (h *HTTPHandler) ResetQuiz(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.ResetQuiz(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to reset quiz: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Quiz reset successfully",
+  })
+}
+
+// NextRound ends the intermission and shows the first question of the next round (host only)
+// APi /api/v1/quizzes/:id/rounds/next [POST]
+func (h *HTTPHandler) NextRound(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  question, err := h.quizService.StartNextRound(quiz.ID)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to start next round: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message":        "Started next round",
+    "round":          quiz.CurrentRound(),
+    "question":       question,
+    "question_index": quiz.CurrentQuestionIndex(),
+  })
+}
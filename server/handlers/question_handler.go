@@ -0,0 +1,159 @@
+package handlers
+
+import (
+  "btaskee-quiz/models"
+  "net/http"
+  "strings"
+
+  "github.com/gin-gonic/gin"
+)
+
+// CreateQuestion adds a question to the question bank (admin only)
+// APi /api/v1/questions [POST]
+func (h *HTTPHandler) CreateQuestion(c *gin.Context) {
+  if !h.requireAdmin(c) {
+    return
+  }
+
+  var question models.Question
+
+  if err := c.ShouldBindJSON(&question); err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Invalid question: " + err.Error(),
+    })
+    return
+  }
+
+  created, err := h.quizService.QuestionBank.CreateQuestion(question)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to create question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message":  "Question created successfully",
+    "question": created,
+  })
+}
+
+// ListQuestions lists bank questions, optionally filtered by category and tags. Only admins
+// see the correct answers.
+// APi /api/v1/questions?category=&tag= [GET]
+func (h *HTTPHandler) ListQuestions(c *gin.Context) {
+  category := c.Query("category")
+
+  tags := make([]string, 0)
+  for _, tag := range c.QueryArray("tag") {
+    for _, part := range strings.Split(tag, ",") {
+      if part = strings.TrimSpace(part); part != "" {
+        tags = append(tags, part)
+      }
+    }
+  }
+
+  questions, err := h.quizService.QuestionBank.ListQuestions(category, tags)
+  if err != nil {
+    c.JSON(http.StatusInternalServerError, gin.H{
+      "error": "Failed to list questions: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "questions": bankViews(questions, h.isAdmin(c)),
+    "count":     len(questions),
+  })
+}
+
+// GetQuestion retrieves a bank question by ID. Only admins see the correct answer.
+// APi /api/v1/questions/:id [GET]
+func (h *HTTPHandler) GetQuestion(c *gin.Context) {
+  questionID := c.Param("id")
+
+  question, err := h.quizService.QuestionBank.GetQuestion(questionID)
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Question not found: " + err.Error(),
+    })
+    return
+  }
+
+  if !h.isAdmin(c) {
+    c.JSON(http.StatusOK, gin.H{
+      "question": question.ParticipantView(nil),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "question": question,
+  })
+}
+
+// UpdateQuestion replaces a bank question (admin only)
+// APi /api/v1/questions/:id [PUT]
+func (h *HTTPHandler) UpdateQuestion(c *gin.Context) {
+  if !h.requireAdmin(c) {
+    return
+  }
+
+  questionID := c.Param("id")
+
+  var question models.Question
+  if err := c.ShouldBindJSON(&question); err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Invalid question: " + err.Error(),
+    })
+    return
+  }
+
+  updated, err := h.quizService.QuestionBank.UpdateQuestion(questionID, question)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to update question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message":  "Question updated successfully",
+    "question": updated,
+  })
+}
+
+// DeleteQuestion removes a question from the bank (admin only)
+// APi /api/v1/questions/:id [DELETE]
+func (h *HTTPHandler) DeleteQuestion(c *gin.Context) {
+  if !h.requireAdmin(c) {
+    return
+  }
+
+  questionID := c.Param("id")
+
+  err := h.quizService.QuestionBank.DeleteQuestion(questionID)
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Failed to delete question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Question deleted successfully",
+  })
+}
+
+// bankViews returns bank questions as admins see them, or without their answers for everyone else
+func bankViews(questions []models.Question, isAdmin bool) interface{} {
+  if isAdmin {
+    return questions
+  }
+
+  views := make([]models.ParticipantQuestion, 0, len(questions))
+  for i := range questions {
+    views = append(views, questions[i].ParticipantView(nil))
+  }
+  return views
+}
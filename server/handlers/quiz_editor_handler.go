@@ -0,0 +1,141 @@
+package handlers
+
+import (
+  "btaskee-quiz/models"
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// AddQuizQuestion adds a question to a waiting quiz (host only)
+// APi /api/v1/quizzes/:id/questions [POST]
+func (h *HTTPHandler) AddQuizQuestion(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  var question models.Question
+  if err := c.ShouldBindJSON(&question); err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Invalid question: " + err.Error(),
+    })
+    return
+  }
+
+  added, err := h.quizService.AddQuestion(quiz.ID, question)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to add question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message":  "Question added successfully",
+    "question": added,
+  })
+}
+
+// UpdateQuizQuestion replaces a question of a waiting quiz (host only)
+// APi /api/v1/quizzes/:id/questions/:questionId [PUT]
+func (h *HTTPHandler) UpdateQuizQuestion(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  var question models.Question
+  if err := c.ShouldBindJSON(&question); err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Invalid question: " + err.Error(),
+    })
+    return
+  }
+
+  updated, err := h.quizService.UpdateQuestion(quiz.ID, c.Param("questionId"), question)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to update question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message":  "Question updated successfully",
+    "question": updated,
+  })
+}
+
+// DeleteQuizQuestion removes a question from a waiting quiz (host only)
+// APi /api/v1/quizzes/:id/questions/:questionId [DELETE]
+func (h *HTTPHandler) DeleteQuizQuestion(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  err := h.quizService.DeleteQuestion(quiz.ID, c.Param("questionId"))
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to delete question: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Question deleted successfully",
+  })
+}
+
+// ReorderQuizQuestions sets the question order of a waiting quiz (host only)
+// APi /api/v1/quizzes/:id/questions/order [PUT]
+func (h *HTTPHandler) ReorderQuizQuestions(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  var request struct {
+    QuestionIDs []string `json:"question_ids" binding:"required"`
+  }
+  if err := c.ShouldBindJSON(&request); err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "question_ids is required",
+    })
+    return
+  }
+
+  err := h.quizService.ReorderQuestions(quiz.ID, request.QuestionIDs)
+  if err != nil {
+    c.JSON(errorStatus(err), gin.H{
+      "error": "Failed to reorder questions: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Questions reordered successfully",
+  })
+}
+
+// hostQuiz loads the quiz from the :id parameter and checks that the caller is its host.
+// It writes the error response and returns false otherwise.
+func (h *HTTPHandler) hostQuiz(c *gin.Context) (*models.Quiz, bool) {
+  quiz, err := h.quizService.GetQuiz(c.Param("id"))
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Quiz not found: " + err.Error(),
+    })
+    return nil, false
+  }
+
+  if !h.isHost(c, quiz) {
+    c.JSON(http.StatusForbidden, gin.H{
+      "error": "Host token is required",
+    })
+    return nil, false
+  }
+
+  return quiz, true
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+  "btaskee-quiz/models"
+  "encoding/csv"
+  "encoding/json"
+  "fmt"
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// GetResults exports quiz results as a CSV or JSON download.
+// CSV exports one sheet at a time: ?sheet=answers (default) for one row per participant
+// per question, or ?sheet=summary for final leaderboard positions. JSON includes both.
+// APi /api/v1/quizzes/:id/results?format=csv|json&sheet=answers|summary [GET]
+func (h *HTTPHandler) GetResults(c *gin.Context) {
+  quizID := c.Param("id")
+  if quizID == "" {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Quiz ID is required",
+    })
+    return
+  }
+
+  quiz, err := h.quizService.GetQuiz(quizID)
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Quiz not found: " + err.Error(),
+    })
+    return
+  }
+
+  // Results reveal every answer, so they are only available to hosts until the quiz ends
+  if quiz.GetStatus() != models.QuizStatusEnded && !h.isHost(c, quiz) {
+    c.JSON(http.StatusForbidden, gin.H{
+      "error": "Results are available to the host until the quiz ends",
+    })
+    return
+  }
+
+  leaderboard := quiz.GetLeaderboard()
+
+  switch c.DefaultQuery("format", "json") {
+  case "csv":
+    switch c.DefaultQuery("sheet", "answers") {
+    case "answers":
+      h.streamResultsCSV(c, quiz, leaderboard)
+    case "summary":
+      h.writeSummaryCSV(c, quiz, leaderboard)
+    default:
+      c.JSON(http.StatusBadRequest, gin.H{
+        "error": "Sheet must be answers or summary",
+      })
+    }
+  case "json":
+    h.streamResultsJSON(c, quiz, leaderboard)
+  default:
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Format must be csv or json",
+    })
+  }
+}
+
+// streamResultsCSV writes answer rows as they are produced, flushing per participant
+func (h *HTTPHandler) streamResultsCSV(c *gin.Context, quiz *models.Quiz, leaderboard []models.LeaderboardEntry) {
+  setDownloadHeaders(c, "text/csv; charset=utf-8", fmt.Sprintf("quiz-%s-results.csv", quiz.ID))
+
+  writer := csv.NewWriter(c.Writer)
+  writer.Write(models.ResultRowHeader)
+
+  lastUserID := ""
+  err := h.quizService.EachResultRow(quiz, leaderboard, func(row models.ResultRow) error {
+    if row.UserID != lastUserID && lastUserID != "" {
+      writer.Flush()
+      c.Writer.Flush()
+    }
+    lastUserID = row.UserID
+    return writer.Write(row.CSVRecord())
+  })
+  if err != nil {
+    c.Error(err)
+  }
+
+  writer.Flush()
+}
+
+// writeSummaryCSV writes the final leaderboard positions
+func (h *HTTPHandler) writeSummaryCSV(c *gin.Context, quiz *models.Quiz, leaderboard []models.LeaderboardEntry) {
+  setDownloadHeaders(c, "text/csv; charset=utf-8", fmt.Sprintf("quiz-%s-summary.csv", quiz.ID))
+
+  writer := csv.NewWriter(c.Writer)
+  writer.Write(models.LeaderboardHeader)
+  for _, entry := range leaderboard {
+    writer.Write(entry.CSVRecord())
+  }
+  writer.Flush()
+}
+
+// streamResultsJSON writes {"quiz_id", "title", "summary", "answers"} encoding answer rows one by one
+func (h *HTTPHandler) streamResultsJSON(c *gin.Context, quiz *models.Quiz, leaderboard []models.LeaderboardEntry) {
+  setDownloadHeaders(c, "application/json; charset=utf-8", fmt.Sprintf("quiz-%s-results.json", quiz.ID))
+
+  header, err := json.Marshal(gin.H{
+    "quiz_id": quiz.ID,
+    "title":   quiz.Title,
+    "summary": leaderboard,
+  })
+  if err != nil {
+    c.Error(err)
+    return
+  }
+
+  // Reopen the header object to append the answers array
+  c.Writer.Write(header[:len(header)-1])
+  c.Writer.WriteString(`,"answers":[`)
+
+  first := true
+  err = h.quizService.EachResultRow(quiz, leaderboard, func(row models.ResultRow) error {
+    data, err := json.Marshal(row)
+    if err != nil {
+      return err
+    }
+    if !first {
+      c.Writer.WriteString(",")
+    }
+    first = false
+    _, err = c.Writer.Write(data)
+    return err
+  })
+  if err != nil {
+    c.Error(err)
+  }
+
+  c.Writer.WriteString("]}")
+}
+
+func setDownloadHeaders(c *gin.Context, contentType, filename string) {
+  c.Header("Content-Type", contentType)
+  c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+  c.Status(http.StatusOK)
+}
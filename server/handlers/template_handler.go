@@ -0,0 +1,185 @@
+package handlers
+
+import (
+  "btaskee-quiz/models"
+  "errors"
+  "io"
+  "net/http"
+
+  "github.com/gin-gonic/gin"
+)
+
+// CloneQuiz creates a fresh waiting quiz from an existing quiz (host only)
+// APi /api/v1/quizzes/:id/clone [POST]
+func (h *HTTPHandler) CloneQuiz(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  request, ok := bindCopyRequest(c)
+  if !ok {
+    return
+  }
+
+  clone, err := h.quizService.CloneQuiz(quiz.ID, request.Title)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to clone quiz: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message": "Quiz cloned successfully",
+    "quiz":    clone,
+  })
+}
+
+// SaveQuizTemplate saves an existing quiz's definition as a template (host only)
+// APi /api/v1/quizzes/:id/template [POST]
+func (h *HTTPHandler) SaveQuizTemplate(c *gin.Context) {
+  quiz, ok := h.hostQuiz(c)
+  if !ok {
+    return
+  }
+
+  request, ok := bindCopyRequest(c)
+  if !ok {
+    return
+  }
+
+  template, err := h.quizService.SaveQuizAsTemplate(quiz.ID, request.Title)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to save template: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message":  "Template saved successfully",
+    "template": template,
+  })
+}
+
+// CreateTemplate saves a quiz definition as a template without creating a quiz
+// APi /api/v1/templates [POST]
+func (h *HTTPHandler) CreateTemplate(c *gin.Context) {
+  var request models.CreateTemplateRequest
+
+  if err := c.ShouldBindJSON(&request); err != nil || request.Title == "" {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Title is required",
+    })
+    return
+  }
+
+  template, err := h.quizService.Templates.CreateTemplate(request.Title, request.Questions, request.Rounds, request.Settings)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to create template: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message":  "Template created successfully",
+    "template": template,
+  })
+}
+
+// ListTemplates lists all saved templates, with their answer keys (admin only)
+// APi /api/v1/templates [GET]
+func (h *HTTPHandler) ListTemplates(c *gin.Context) {
+  if !h.requireAdmin(c) {
+    return
+  }
+
+  templates, err := h.quizService.Templates.ListTemplates()
+  if err != nil {
+    c.JSON(http.StatusInternalServerError, gin.H{
+      "error": "Failed to list templates: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "templates": templates,
+    "count":     len(templates),
+  })
+}
+
+// GetTemplate retrieves a template by ID, with its answer key (admin only)
+// APi /api/v1/templates/:id [GET]
+func (h *HTTPHandler) GetTemplate(c *gin.Context) {
+  if !h.requireAdmin(c) {
+    return
+  }
+
+  template, err := h.quizService.Templates.GetTemplate(c.Param("id"))
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Template not found: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "template": template,
+  })
+}
+
+// DeleteTemplate removes a template (admin only)
+// APi /api/v1/templates/:id [DELETE]
+func (h *HTTPHandler) DeleteTemplate(c *gin.Context) {
+  if !h.requireAdmin(c) {
+    return
+  }
+
+  err := h.quizService.Templates.DeleteTemplate(c.Param("id"))
+  if err != nil {
+    c.JSON(http.StatusNotFound, gin.H{
+      "error": "Failed to delete template: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusOK, gin.H{
+    "message": "Template deleted successfully",
+  })
+}
+
+// InstantiateTemplate creates a new waiting quiz from a template
+// APi /api/v1/templates/:id/quizzes [POST]
+func (h *HTTPHandler) InstantiateTemplate(c *gin.Context) {
+  request, ok := bindCopyRequest(c)
+  if !ok {
+    return
+  }
+
+  quiz, err := h.quizService.InstantiateTemplate(c.Param("id"), request.Title)
+  if err != nil {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Failed to create quiz: " + err.Error(),
+    })
+    return
+  }
+
+  c.JSON(http.StatusCreated, gin.H{
+    "message": "Quiz created successfully",
+    "quiz":    quiz,
+  })
+}
+
+// bindCopyRequest binds the optional title override; an empty body is allowed
+func bindCopyRequest(c *gin.Context) (models.CopyQuizRequest, bool) {
+  var request models.CopyQuizRequest
+  if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+    c.JSON(http.StatusBadRequest, gin.H{
+      "error": "Invalid request: " + err.Error(),
+    })
+    return request, false
+  }
+  return request, true
+}
@@ -141,12 +141,30 @@ func (h *WebSocketHandler) handleMessage(client *services.Client, message []byte
   switch wsMessage.Type {
   case "join_quiz":
     h.handleJoinQuiz(client, wsMessage.Payload)
+  case "host_quiz":
+    h.handleHostQuiz(client, wsMessage.Payload)
   case "submit_answer":
     h.handleSubmitAnswer(client, wsMessage.Payload)
   case "start_quiz":
-    h.handleStartQuiz(client, wsMessage.Payload)
+    h.handleStartQuiz(client)
   case "end_quiz":
-    h.handleEndQuiz(client, wsMessage.Payload)
+    h.handleEndQuiz(client)
+  case "next_question":
+    h.handleMoveQuestion(client, 1)
+  case "previous_question":
+    h.handleMoveQuestion(client, -1)
+  case "pause_quiz":
+    h.handlePauseQuiz(client, true)
+  case "resume_quiz":
+    h.handlePauseQuiz(client, false)
+  case "reset_quiz":
+    h.handleResetQuiz(client)
+  case "next_round":
+    h.handleNextRound(client)
+  case "lock_quiz":
+    h.handleLockQuiz(client, true)
+  case "unlock_quiz":
+    h.handleLockQuiz(client, false)
   default:
     h.sendError(client, "Unknown message type: "+wsMessage.Type)
   }
@@ -199,7 +217,7 @@ func (h *WebSocketHandler) handleJoinQuiz(client *services.Client, payload inter
     h.sendMessage(client, models.WebSocketMessage{
       Type: "quiz_state",
       Payload: map[string]interface{}{
-        "quiz":        quiz,
+        "quiz":        quiz.ViewFor(false, user),
         "leaderboard": quiz.GetLeaderboard(),
       },
     })
@@ -208,6 +226,50 @@ func (h *WebSocketHandler) handleJoinQuiz(client *services.Client, payload inter
   log.Printf("👤 User %s joined quiz %s via WebSocket", user.Name, joinRequest.QuizID)
 }
 
+// handleHostQuiz attaches a host connection to a quiz without joining as a participant
+func (h *WebSocketHandler) handleHostQuiz(client *services.Client, payload interface{}) {
+  payloadBytes, err := json.Marshal(payload)
+  if err != nil {
+    h.sendError(client, "Invalid payload")
+    return
+  }
+
+  var hostRequest struct {
+    QuizID    string `json:"quiz_id"`
+    HostToken string `json:"host_token"`
+  }
+  err = json.Unmarshal(payloadBytes, &hostRequest)
+  if err != nil {
+    h.sendError(client, "Invalid host request")
+    return
+  }
+
+  quiz, err := h.quizService.GetQuiz(hostRequest.QuizID)
+  if err != nil {
+    h.sendError(client, "Quiz not found: "+err.Error())
+    return
+  }
+
+  if !h.quizService.IsHost(quiz, hostRequest.HostToken) {
+    h.sendError(client, "Invalid host token")
+    return
+  }
+
+  // Update client info
+  client.QuizID = hostRequest.QuizID
+  client.IsHost = true
+
+  h.sendMessage(client, models.WebSocketMessage{
+    Type: "quiz_state",
+    Payload: map[string]interface{}{
+      "quiz":        quiz.ViewFor(true, nil),
+      "leaderboard": quiz.GetLeaderboard(),
+    },
+  })
+
+  log.Printf("🎤 Host connected to quiz %s via WebSocket", hostRequest.QuizID)
+}
+
 // handleSubmitAnswer handles answer submission
 func (h *WebSocketHandler) handleSubmitAnswer(client *services.Client, payload interface{}) {
   if client.QuizID == "" || client.UserID == "" {
@@ -247,68 +309,127 @@ func (h *WebSocketHandler) handleSubmitAnswer(client *services.Client, payload i
   log.Printf("✅ Answer submitted for user %s, question %s", client.UserID, submitRequest.QuestionID)
 }
 
-// handleStartQuiz handles quiz start requests
-func (h *WebSocketHandler) handleStartQuiz(client *services.Client, payload interface{}) {
-  if client.QuizID == "" {
-    h.sendError(client, "Must join a quiz first")
+// handleStartQuiz handles start_quiz requests from the host
+func (h *WebSocketHandler) handleStartQuiz(client *services.Client) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can start the quiz")
     return
   }
 
-  payloadBytes, err := json.Marshal(payload)
+  err := h.quizService.StartQuiz(client.QuizID)
   if err != nil {
-    h.sendError(client, "Invalid payload")
+    h.sendError(client, "Failed to start quiz: "+err.Error())
     return
   }
 
-  var startRequest struct {
-    QuizID string `json:"quiz_id"`
+  log.Printf("🚀 Quiz %s started via WebSocket", client.QuizID)
+}
+
+// handleEndQuiz handles end_quiz requests from the host
+func (h *WebSocketHandler) handleEndQuiz(client *services.Client) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can end the quiz")
+    return
   }
-  err = json.Unmarshal(payloadBytes, &startRequest)
+
+  err := h.quizService.EndQuiz(client.QuizID)
   if err != nil {
-    h.sendError(client, "Invalid start request")
+    h.sendError(client, "Failed to end quiz: "+err.Error())
+    return
+  }
+
+  log.Printf("🏁 Quiz %s ended via WebSocket", client.QuizID)
+}
+
+// handleMoveQuestion handles next_question and previous_question requests from the host
+func (h *WebSocketHandler) handleMoveQuestion(client *services.Client, step int) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can change the current question")
     return
   }
 
-  // Start the quiz
-  err = h.quizService.StartQuiz(startRequest.QuizID)
+  var err error
+  if step > 0 {
+    _, err = h.quizService.NextQuestion(client.QuizID)
+  } else {
+    _, err = h.quizService.PreviousQuestion(client.QuizID)
+  }
   if err != nil {
-    h.sendError(client, "Failed to start quiz: "+err.Error())
+    h.sendError(client, "Failed to change question: "+err.Error())
     return
   }
 
-  log.Printf("🚀 Quiz %s started via WebSocket", startRequest.QuizID)
+  log.Printf("➡️  Quiz %s question changed via WebSocket", client.QuizID)
 }
 
-// handleEndQuiz handles quiz end requests
-func (h *WebSocketHandler) handleEndQuiz(client *services.Client, payload interface{}) {
-  if client.QuizID == "" {
-    h.sendError(client, "Must join a quiz first")
+// handleNextRound handles next_round requests from the host
+func (h *WebSocketHandler) handleNextRound(client *services.Client) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can start the next round")
     return
   }
 
-  payloadBytes, err := json.Marshal(payload)
+  if _, err := h.quizService.StartNextRound(client.QuizID); err != nil {
+    h.sendError(client, "Failed to start next round: "+err.Error())
+    return
+  }
+
+  log.Printf("🎬 Quiz %s next round started via WebSocket", client.QuizID)
+}
+
+// handlePauseQuiz handles pause_quiz and resume_quiz requests from the host
+func (h *WebSocketHandler) handlePauseQuiz(client *services.Client, pause bool) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can pause or resume the quiz")
+    return
+  }
+
+  var err error
+  action := "resumed"
+  if pause {
+    action = "paused"
+    err = h.quizService.PauseQuiz(client.QuizID)
+  } else {
+    err = h.quizService.ResumeQuiz(client.QuizID)
+  }
   if err != nil {
-    h.sendError(client, "Invalid payload")
+    h.sendError(client, "Failed to change quiz state: "+err.Error())
     return
   }
 
-  var endRequest struct {
-    QuizID string `json:"quiz_id"`
+  log.Printf("⏯️  Quiz %s %s via WebSocket", client.QuizID, action)
+}
+
+// handleResetQuiz handles reset_quiz requests from the host
+func (h *WebSocketHandler) handleResetQuiz(client *services.Client) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can reset the quiz")
+    return
   }
-  err = json.Unmarshal(payloadBytes, &endRequest)
+
+  err := h.quizService.ResetQuiz(client.QuizID)
   if err != nil {
-    h.sendError(client, "Invalid end request")
+    h.sendError(client, "Failed to reset quiz: "+err.Error())
+    return
+  }
+
+  log.Printf("🔄 Quiz %s reset via WebSocket", client.QuizID)
+}
+
+// handleLockQuiz handles lock_quiz and unlock_quiz requests from the host
+func (h *WebSocketHandler) handleLockQuiz(client *services.Client, locked bool) {
+  if client.QuizID == "" || !client.IsHost {
+    h.sendError(client, "Only the host can lock or unlock the lobby")
     return
   }
 
-  // End the quiz
-  err = h.quizService.EndQuiz(endRequest.QuizID)
+  err := h.quizService.SetLobbyLocked(client.QuizID, locked)
   if err != nil {
-    h.sendError(client, "Failed to end quiz: "+err.Error())
+    h.sendError(client, "Failed to change lobby lock: "+err.Error())
     return
   }
 
-  log.Printf("🏁 Quiz %s ended via WebSocket", endRequest.QuizID)
+  log.Printf("🚪 Quiz %s lobby lock set to %v via WebSocket", client.QuizID, locked)
 }
 
 // sendMessage sends a message to a specific client
@@ -0,0 +1,79 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "strings"
+)
+
+// parseAiken parses the Moodle Aiken format: a question line, lettered options
+// ("A. ..." or "A) ...") and an "ANSWER: X" line, with blank lines between questions.
+func parseAiken(text string, result *Result) {
+  var question *models.Question
+  startLine := 0
+  skipping := false
+
+  reset := func() {
+    question = nil
+    skipping = false
+  }
+
+  for i, rawLine := range strings.Split(text, "\n") {
+    lineNumber := i + 1
+    line := strings.TrimSpace(rawLine)
+
+    if line == "" {
+      if question != nil && !skipping {
+        result.addError(atLine(startLine), "question has no ANSWER line")
+      }
+      reset()
+      continue
+    }
+
+    // Lines after an error or an ANSWER line are skipped until the next blank line
+    if skipping {
+      continue
+    }
+
+    if question == nil {
+      question = &models.Question{Text: line}
+      startLine = lineNumber
+      continue
+    }
+
+    upper := strings.ToUpper(line)
+    if strings.HasPrefix(upper, "ANSWER:") {
+      index, ok := letterIndex(strings.TrimSpace(line[len("ANSWER:"):]))
+      if !ok || index >= len(question.Options) {
+        result.addError(atLine(lineNumber), fmt.Sprintf("invalid answer %q", line))
+      } else {
+        question.Correct = index
+        result.addQuestion(*question, atLine(startLine))
+      }
+      // The question is complete; anything else before the blank line is ignored
+      skipping = true
+      continue
+    }
+
+    if len(line) >= 2 && (line[1] == '.' || line[1] == ')') {
+      index, ok := letterIndex(line[:1])
+      if ok && index == len(question.Options) {
+        question.Options = append(question.Options, strings.TrimSpace(line[2:]))
+        continue
+      }
+    }
+
+    if len(question.Options) == 0 {
+      // Multi-line question text
+      question.Text += " " + line
+      continue
+    }
+
+    result.addError(atLine(lineNumber), fmt.Sprintf("expected option %c or ANSWER line", 'A'+len(question.Options)))
+    skipping = true
+  }
+
+  if question != nil && !skipping {
+    result.addError(atLine(startLine), "question has no ANSWER line")
+  }
+}
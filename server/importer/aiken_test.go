@@ -0,0 +1,95 @@
+package importer
+
+import (
+  "reflect"
+  "testing"
+)
+
+func TestParseAiken(t *testing.T) {
+  tests := []struct {
+    name          string
+    input         string
+    wantQuestions int
+    wantLines     []int
+  }{
+    {
+      name:          "valid questions",
+      input:         "What is 2+2?\nA. 3\nB) 4\nANSWER: B\n\nWhich is a color?\nA. Red\nB. Cat\nANSWER: A\n",
+      wantQuestions: 2,
+      wantLines:     []int{},
+    },
+    {
+      name:          "multi-line question text",
+      input:         "Which of these\nis a fruit?\nA. Apple\nB. Rock\nANSWER: A",
+      wantQuestions: 1,
+      wantLines:     []int{},
+    },
+    {
+      name:          "missing ANSWER line",
+      input:         "What is 2+2?\nA. 3\nB. 4\n\nWhich is a color?\nA. Red\nB. Cat\nANSWER: A\n",
+      wantQuestions: 1,
+      wantLines:     []int{1},
+    },
+    {
+      name:          "missing ANSWER line at end of input",
+      input:         "What is 2+2?\nA. 3\nB. 4",
+      wantQuestions: 0,
+      wantLines:     []int{1},
+    },
+    {
+      name:          "answer out of range",
+      input:         "What is 2+2?\nA. 3\nB. 4\nANSWER: C\n",
+      wantQuestions: 0,
+      wantLines:     []int{4},
+    },
+    {
+      name:          "answer is not a letter",
+      input:         "What is 2+2?\nA. 3\nB. 4\nANSWER: 4\n",
+      wantQuestions: 0,
+      wantLines:     []int{4},
+    },
+    {
+      name:          "options out of order",
+      input:         "What is 2+2?\nA. 3\nC. 4\nANSWER: A\n\nWhich is a color?\nA. Red\nB. Cat\nANSWER: A\n",
+      wantQuestions: 1,
+      wantLines:     []int{3},
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      result, err := Parse(FormatAiken, []byte(tt.input))
+      if err != nil {
+        t.Fatal(err)
+      }
+      if len(result.Questions) != tt.wantQuestions {
+        t.Errorf("got %d questions, want %d", len(result.Questions), tt.wantQuestions)
+      }
+      lines := make([]int, 0)
+      for _, importErr := range result.Errors {
+        lines = append(lines, importErr.Line)
+      }
+      if !reflect.DeepEqual(lines, tt.wantLines) {
+        t.Errorf("error lines = %v (%v), want %v", lines, result.Errors, tt.wantLines)
+      }
+    })
+  }
+}
+
+func TestParseAikenQuestion(t *testing.T) {
+  result, err := Parse(FormatAiken, []byte("Which of these\nis a fruit?\nA. Rock\nB. Apple\nANSWER: b\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(result.Questions) != 1 {
+    t.Fatalf("got %d questions, errors %v", len(result.Questions), result.Errors)
+  }
+
+  question := result.Questions[0]
+  if question.Text != "Which of these is a fruit?" {
+    t.Errorf("text = %q", question.Text)
+  }
+  if !reflect.DeepEqual(question.Options, []string{"Rock", "Apple"}) || question.Correct != 1 {
+    t.Errorf("options = %v, correct %d; want [Rock Apple], correct 1", question.Options, question.Correct)
+  }
+}
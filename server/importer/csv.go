@@ -0,0 +1,190 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "encoding/csv"
+  "fmt"
+  "io"
+  "sort"
+  "strconv"
+  "strings"
+)
+
+// parseCSV parses a spreadsheet export with a header row.
+//
+// Recognized columns: id, type, text, option_1..option_N (or a "|" separated options column),
+// correct, points, category, tags, numeric_mode and tolerance. The correct column holds an option
+// letter for single choice, true/false for true/false, "|" separated letters for multi-select and
+// ordering, "|" separated accepted answers for free text and a number for numeric questions.
+func parseCSV(text string, result *Result) {
+  reader := csv.NewReader(strings.NewReader(text))
+  reader.FieldsPerRecord = -1
+  reader.TrimLeadingSpace = true
+
+  header, err := reader.Read()
+  if err != nil {
+    result.addError(atLine(1), "missing header row: "+err.Error())
+    return
+  }
+
+  columns := make(map[string]int)
+  optionColumns := make([]int, 0)
+  optionNumbers := make(map[int]int)
+  for i, name := range header {
+    name = strings.ToLower(strings.TrimSpace(name))
+    if strings.HasPrefix(name, "option_") {
+      n, err := strconv.Atoi(strings.TrimPrefix(name, "option_"))
+      if err == nil {
+        optionColumns = append(optionColumns, i)
+        optionNumbers[i] = n
+        continue
+      }
+    }
+    columns[name] = i
+  }
+  sort.Slice(optionColumns, func(a, b int) bool {
+    return optionNumbers[optionColumns[a]] < optionNumbers[optionColumns[b]]
+  })
+
+  if _, ok := columns["text"]; !ok {
+    result.addError(atLine(1), "header must contain a text column")
+    return
+  }
+  if _, ok := columns["correct"]; !ok {
+    result.addError(atLine(1), "header must contain a correct column")
+    return
+  }
+
+  for {
+    record, err := reader.Read()
+    if err == io.EOF {
+      break
+    }
+    if err != nil {
+      if parseErr, ok := err.(*csv.ParseError); ok {
+        result.addError(atLine(parseErr.StartLine), parseErr.Err.Error())
+        continue
+      }
+      result.addError(ImportError{}, err.Error())
+      return
+    }
+    line, _ := reader.FieldPos(0)
+
+    cell := func(name string) string {
+      if i, ok := columns[name]; ok && i < len(record) {
+        return strings.TrimSpace(record[i])
+      }
+      return ""
+    }
+
+    // Skip blank rows
+    if strings.TrimSpace(strings.Join(record, "")) == "" {
+      continue
+    }
+
+    question := models.Question{
+      ID:       cell("id"),
+      Type:     models.QuestionType(strings.ToLower(cell("type"))),
+      Text:     cell("text"),
+      Category: cell("category"),
+      Tags:     splitList(cell("tags")),
+    }
+
+    if len(optionColumns) > 0 {
+      for _, i := range optionColumns {
+        if i < len(record) && strings.TrimSpace(record[i]) != "" {
+          question.Options = append(question.Options, strings.TrimSpace(record[i]))
+        }
+      }
+    } else {
+      question.Options = splitList(cell("options"))
+    }
+
+    if points := cell("points"); points != "" {
+      question.Points, err = strconv.Atoi(points)
+      if err != nil {
+        result.addError(atLine(line), fmt.Sprintf("invalid points %q", points))
+        continue
+      }
+    }
+
+    if err := applyCSVCorrect(&question, cell("correct"), cell("numeric_mode"), cell("tolerance")); err != nil {
+      result.addError(atLine(line), err.Error())
+      continue
+    }
+
+    result.addQuestion(question, atLine(line))
+  }
+}
+
+// applyCSVCorrect fills in the correctness fields from the correct column according to the question type
+func applyCSVCorrect(question *models.Question, correct, numericMode, tolerance string) error {
+  if correct == "" {
+    return fmt.Errorf("correct column is empty")
+  }
+
+  switch question.GetType() {
+  case models.QuestionTypeSingleChoice:
+    index, err := parseOptionRef(correct)
+    if err != nil {
+      return err
+    }
+    question.Correct = index
+  case models.QuestionTypeTrueFalse:
+    switch strings.ToLower(correct) {
+    case "true", "t", "yes":
+      question.Correct = 0
+    case "false", "f", "no":
+      question.Correct = 1
+    default:
+      return fmt.Errorf("invalid true/false answer %q", correct)
+    }
+  case models.QuestionTypeMultiSelect, models.QuestionTypeOrdering:
+    indexes := make([]int, 0)
+    for _, ref := range splitList(correct) {
+      index, err := parseOptionRef(ref)
+      if err != nil {
+        return err
+      }
+      indexes = append(indexes, index)
+    }
+    if question.GetType() == models.QuestionTypeMultiSelect {
+      question.CorrectSet = indexes
+    } else {
+      question.CorrectOrder = indexes
+    }
+  case models.QuestionTypeFreeText:
+    question.AcceptedAnswers = splitList(correct)
+  case models.QuestionTypeNumeric:
+    number, err := strconv.ParseFloat(correct, 64)
+    if err != nil {
+      return fmt.Errorf("invalid number %q", correct)
+    }
+    question.CorrectNumber = &number
+    if numericMode != "" || tolerance != "" {
+      question.Numeric = &models.NumericOptions{Mode: models.NumericMode(strings.ToLower(numericMode))}
+      if tolerance != "" {
+        question.Numeric.Tolerance, err = strconv.ParseFloat(tolerance, 64)
+        if err != nil {
+          return fmt.Errorf("invalid tolerance %q", tolerance)
+        }
+      }
+      if question.Numeric.Mode == "" {
+        question.Numeric.Mode = models.NumericModeAbsolute
+      }
+    }
+  }
+  return nil
+}
+
+// parseOptionRef accepts an option letter (A, B, ...) or a 1-based option number
+func parseOptionRef(ref string) (int, error) {
+  if index, ok := letterIndex(ref); ok {
+    return index, nil
+  }
+  number, err := strconv.Atoi(strings.TrimSpace(ref))
+  if err != nil || number < 1 {
+    return 0, fmt.Errorf("invalid option reference %q, use a letter or 1-based number", ref)
+  }
+  return number - 1, nil
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "reflect"
+  "testing"
+)
+
+func TestParseCSVQuestionTypes(t *testing.T) {
+  input := `id,type,text,option_1,option_2,option_3,correct,points,category,tags,numeric_mode,tolerance
+s1,,Capital of France?,Berlin,Paris,Rome,B,5,Geography,europe|capitals,,
+s2,single_choice,Two plus two?,3,4,5,2,,,,,
+t1,true_false,The sky is blue,,,,true,,,,,
+m1,multi_select,Pick the primes,2,4,5,A|C,,,,,
+o1,ordering,Smallest first,3,1,2,B|C|A,,,,,
+f1,free_text,Capital of Vietnam?,,,,Hà Nội|Ha Noi,,,,,
+n1,numeric,Speed of sound (m/s)?,,,,343,,,,absolute,10
+n2,numeric,Pi?,,,,3.14159,,,,,
+`
+  result, err := Parse(FormatCSV, []byte(input))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(result.Errors) != 0 {
+    t.Fatalf("unexpected errors: %v", result.Errors)
+  }
+
+  questions := make(map[string]*models.Question)
+  for i := range result.Questions {
+    questions[result.Questions[i].ID] = &result.Questions[i]
+  }
+  if len(questions) != 8 {
+    t.Fatalf("got %d questions, want 8", len(questions))
+  }
+
+  s1 := questions["s1"]
+  if s1.GetType() != models.QuestionTypeSingleChoice || s1.Correct != 1 || s1.Points != 5 {
+    t.Errorf("s1 = %+v, want single choice, correct 1, 5 points", s1)
+  }
+  if s1.Category != "Geography" || !reflect.DeepEqual(s1.Tags, []string{"europe", "capitals"}) {
+    t.Errorf("s1 category/tags = %q %v", s1.Category, s1.Tags)
+  }
+  if !reflect.DeepEqual(s1.Options, []string{"Berlin", "Paris", "Rome"}) {
+    t.Errorf("s1 options = %v", s1.Options)
+  }
+  if questions["s2"].Correct != 1 {
+    t.Errorf("s2 correct = %d, want 1 (1-based option number)", questions["s2"].Correct)
+  }
+  if t1 := questions["t1"]; t1.GetType() != models.QuestionTypeTrueFalse || t1.Correct != 0 {
+    t.Errorf("t1 = %+v, want true/false answered true", t1)
+  }
+  if m1 := questions["m1"]; !reflect.DeepEqual(m1.CorrectSet, []int{0, 2}) {
+    t.Errorf("m1 correct set = %v, want [0 2]", m1.CorrectSet)
+  }
+  if o1 := questions["o1"]; !reflect.DeepEqual(o1.CorrectOrder, []int{1, 2, 0}) {
+    t.Errorf("o1 correct order = %v, want [1 2 0]", o1.CorrectOrder)
+  }
+  if f1 := questions["f1"]; !reflect.DeepEqual(f1.AcceptedAnswers, []string{"Hà Nội", "Ha Noi"}) {
+    t.Errorf("f1 accepted answers = %v", f1.AcceptedAnswers)
+  }
+
+  n1 := questions["n1"]
+  if n1.CorrectNumber == nil || *n1.CorrectNumber != 343 {
+    t.Fatalf("n1 correct number = %v, want 343", n1.CorrectNumber)
+  }
+  if numeric := n1.GetNumeric(); numeric.Mode != models.NumericModeAbsolute || numeric.Tolerance != 10 {
+    t.Errorf("n1 numeric = %+v, want absolute within 10", numeric)
+  }
+  if numeric := questions["n2"].GetNumeric(); numeric.Mode != models.NumericModeExact {
+    t.Errorf("n2 numeric mode = %q, want exact", numeric.Mode)
+  }
+}
+
+func TestParseCSVOptionsColumn(t *testing.T) {
+  result, err := Parse(FormatCSV, []byte("text,options,correct\nPick one,Red | Green | Blue,C\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(result.Questions) != 1 {
+    t.Fatalf("got %d questions, errors %v", len(result.Questions), result.Errors)
+  }
+  question := result.Questions[0]
+  if !reflect.DeepEqual(question.Options, []string{"Red", "Green", "Blue"}) || question.Correct != 2 {
+    t.Errorf("question = %+v, want three options with the third correct", question)
+  }
+}
+
+func TestParseCSVOptionColumnsInNumericOrder(t *testing.T) {
+  result, err := Parse(FormatCSV, []byte("text,option_10,option_2,option_1,correct\nPick one,Ten,Two,One,A\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(result.Questions) != 1 {
+    t.Fatalf("got %d questions, errors %v", len(result.Questions), result.Errors)
+  }
+  if options := result.Questions[0].Options; !reflect.DeepEqual(options, []string{"One", "Two", "Ten"}) {
+    t.Errorf("options = %v, want [One Two Ten]", options)
+  }
+}
+
+func TestParseCSVErrors(t *testing.T) {
+  tests := []struct {
+    name      string
+    input     string
+    wantLines []int
+  }{
+    {"missing text column", "question,correct\nx,A\n", []int{1}},
+    {"missing correct column", "text,option_1\nx,A\n", []int{1}},
+    {"empty input", "", []int{1}},
+    {"empty correct", "text,option_1,option_2,correct\nPick,A,B,\n", []int{2}},
+    {"option out of range", "text,option_1,option_2,correct\nPick,A,B,D\n", []int{2}},
+    {"bad option reference", "text,option_1,option_2,correct\nPick,A,B,0\n", []int{2}},
+    {"bad true/false", "type,text,correct\ntrue_false,Sky is blue,maybe\n", []int{2}},
+    {"bad number", "type,text,correct\nnumeric,How many?,lots\n", []int{2}},
+    {"bad tolerance", "type,text,correct,tolerance\nnumeric,How many?,3,wide\n", []int{2}},
+    {"bad points", "text,option_1,option_2,correct,points\nPick,A,B,A,ten\n", []int{2}},
+    {"bad rows are reported, good rows kept", "text,option_1,option_2,correct\nPick,A,B,A\n\nPick,A,B,Z\nPick,A,B,B\n", []int{4}},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      result, err := Parse(FormatCSV, []byte(tt.input))
+      if err != nil {
+        t.Fatal(err)
+      }
+      lines := make([]int, 0)
+      for _, importErr := range result.Errors {
+        lines = append(lines, importErr.Line)
+      }
+      if !reflect.DeepEqual(lines, tt.wantLines) {
+        t.Errorf("error lines = %v (%v), want %v", lines, result.Errors, tt.wantLines)
+      }
+    })
+  }
+}
@@ -0,0 +1,283 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "strconv"
+  "strings"
+)
+
+// GIFT escape sequences are swapped for private-use runes while parsing so that
+// escaped special characters don't act as syntax, then restored in giftUnescape.
+var giftEscapes = strings.NewReplacer(
+  `\\`, "\uE000",
+  `\~`, "\uE001",
+  `\=`, "\uE002",
+  `\#`, "\uE003",
+  `\{`, "\uE004",
+  `\}`, "\uE005",
+  `\:`, "\uE006",
+  `\n`, "\uE007",
+)
+
+var giftUnescapes = strings.NewReplacer(
+  "\uE000", `\`,
+  "\uE001", "~",
+  "\uE002", "=",
+  "\uE003", "#",
+  "\uE004", "{",
+  "\uE005", "}",
+  "\uE006", ":",
+  "\uE007", "\n",
+)
+
+func giftUnescape(text string) string {
+  return strings.TrimSpace(giftUnescapes.Replace(text))
+}
+
+// giftAnswer is one "=" or "~" entry of a GIFT answer block
+type giftAnswer struct {
+  correct bool
+  weight  float64
+  text    string
+}
+
+// parseGIFT parses the Moodle GIFT format. Multiple choice, multiple answers (weighted ~),
+// true/false, short answer and numeric questions are supported; questions are separated
+// by blank lines and "$CATEGORY:" lines set the category for the questions that follow.
+func parseGIFT(text string, result *Result) {
+  category := ""
+  block := make([]string, 0)
+  startLine := 0
+
+  flush := func() {
+    if len(block) > 0 {
+      question, err := parseGIFTQuestion(strings.Join(block, "\n"))
+      if err != nil {
+        result.addError(atLine(startLine), err.Error())
+      } else {
+        question.Category = category
+        result.addQuestion(question, atLine(startLine))
+      }
+    }
+    block = block[:0]
+  }
+
+  for i, rawLine := range strings.Split(giftEscapes.Replace(text), "\n") {
+    line := strings.TrimSpace(rawLine)
+
+    switch {
+    case line == "":
+      flush()
+    case strings.HasPrefix(line, "//"):
+      // Comment
+    case strings.HasPrefix(line, "$CATEGORY:"):
+      flush()
+      category = giftUnescape(line[len("$CATEGORY:"):])
+      // Moodle categories are paths; keep the most specific part
+      if slash := strings.LastIndex(category, "/"); slash >= 0 {
+        category = strings.TrimSpace(category[slash+1:])
+      }
+    default:
+      if len(block) == 0 {
+        startLine = i + 1
+      }
+      block = append(block, line)
+    }
+  }
+  flush()
+}
+
+// parseGIFTQuestion parses a single (escaped) GIFT question block
+func parseGIFTQuestion(block string) (models.Question, error) {
+  var question models.Question
+
+  // Optional ::title::
+  if strings.HasPrefix(block, "::") {
+    end := strings.Index(block[2:], "::")
+    if end < 0 {
+      return question, fmt.Errorf("unterminated question title")
+    }
+    block = strings.TrimSpace(block[end+4:])
+  }
+
+  // Optional text format such as [html] or [markdown]
+  if strings.HasPrefix(block, "[") {
+    if end := strings.Index(block, "]"); end > 0 {
+      block = strings.TrimSpace(block[end+1:])
+    }
+  }
+
+  openBrace := strings.Index(block, "{")
+  closeBrace := strings.LastIndex(block, "}")
+  if openBrace < 0 || closeBrace < openBrace {
+    return question, fmt.Errorf("question has no answer block {...}")
+  }
+
+  // Text after the answer block turns it into a "missing word" question
+  before, after := strings.TrimSpace(block[:openBrace]), strings.TrimSpace(block[closeBrace+1:])
+  question.Text = giftUnescape(before)
+  if after != "" {
+    question.Text = giftUnescape(before + " _____ " + after)
+  }
+
+  body := strings.TrimSpace(block[openBrace+1 : closeBrace])
+  switch {
+  case body == "":
+    return question, fmt.Errorf("essay questions are not supported")
+  case strings.HasPrefix(body, "#"):
+    return question, parseGIFTNumeric(&question, body[1:])
+  }
+
+  switch strings.ToUpper(body) {
+  case "T", "TRUE":
+    question.Type = models.QuestionTypeTrueFalse
+    question.Correct = 0
+    return question, nil
+  case "F", "FALSE":
+    question.Type = models.QuestionTypeTrueFalse
+    question.Correct = 1
+    return question, nil
+  }
+
+  if strings.Contains(body, "->") {
+    return question, fmt.Errorf("matching questions are not supported")
+  }
+
+  answers, err := splitGIFTAnswers(body)
+  if err != nil {
+    return question, err
+  }
+
+  // "~%50%" entries with a positive weight count as correct in multiple-answer questions
+  correct, weighted := 0, false
+  for _, answer := range answers {
+    if answer.correct || answer.weight > 0 {
+      correct++
+    }
+    if answer.weight > 0 && !answer.correct {
+      weighted = true
+    }
+  }
+
+  switch {
+  case correct == 0:
+    return question, fmt.Errorf("question has no correct answer")
+  case correct == len(answers):
+    // Only "=" answers: short answer
+    question.Type = models.QuestionTypeFreeText
+    for _, answer := range answers {
+      question.AcceptedAnswers = append(question.AcceptedAnswers, answer.text)
+    }
+  case weighted || correct > 1:
+    question.Type = models.QuestionTypeMultiSelect
+    for i, answer := range answers {
+      question.Options = append(question.Options, answer.text)
+      if answer.correct || answer.weight > 0 {
+        question.CorrectSet = append(question.CorrectSet, i)
+      }
+    }
+  default:
+    question.Type = models.QuestionTypeSingleChoice
+    for i, answer := range answers {
+      question.Options = append(question.Options, answer.text)
+      if answer.correct {
+        question.Correct = i
+      }
+    }
+  }
+
+  return question, nil
+}
+
+// splitGIFTAnswers splits an answer block into its "=" and "~" entries
+func splitGIFTAnswers(body string) ([]giftAnswer, error) {
+  answers := make([]giftAnswer, 0)
+  start := -1
+  for i, r := range body {
+    if r != '=' && r != '~' {
+      continue
+    }
+    if start >= 0 {
+      answers = append(answers, newGIFTAnswer(body[start:i]))
+    }
+    start = i
+  }
+  if start < 0 {
+    return nil, fmt.Errorf("answer block has no = or ~ entries")
+  }
+  answers = append(answers, newGIFTAnswer(body[start:]))
+  return answers, nil
+}
+
+// newGIFTAnswer parses "=text", "~text", "~%50%text" with optional "#feedback"
+func newGIFTAnswer(entry string) giftAnswer {
+  answer := giftAnswer{correct: entry[0] == '='}
+  entry = strings.TrimSpace(entry[1:])
+
+  if strings.HasPrefix(entry, "%") {
+    if end := strings.Index(entry[1:], "%"); end >= 0 {
+      answer.weight, _ = strconv.ParseFloat(entry[1:end+1], 64)
+      entry = entry[end+2:]
+    }
+  }
+
+  // Drop per-answer feedback
+  if hash := strings.Index(entry, "#"); hash >= 0 {
+    entry = entry[:hash]
+  }
+
+  answer.text = giftUnescape(entry)
+  return answer
+}
+
+// parseGIFTNumeric parses "#value", "#value:tolerance" and "#min..max" numeric answers.
+// When several "=" answers are given, the first full-credit one is used.
+func parseGIFTNumeric(question *models.Question, body string) error {
+  question.Type = models.QuestionTypeNumeric
+  body = strings.TrimSpace(body)
+
+  if strings.HasPrefix(body, "=") {
+    answers, err := splitGIFTAnswers(body)
+    if err != nil {
+      return err
+    }
+    body = answers[0].text
+    for _, answer := range answers {
+      if answer.correct && (answer.weight == 0 || answer.weight == 100) {
+        body = answer.text
+        break
+      }
+    }
+  } else if hash := strings.Index(body, "#"); hash >= 0 {
+    body = body[:hash]
+  }
+  body = giftUnescape(body)
+
+  var value, tolerance float64
+  var err error
+  if low, high, ok := strings.Cut(body, ".."); ok {
+    var lowest, highest float64
+    lowest, err = strconv.ParseFloat(strings.TrimSpace(low), 64)
+    if err == nil {
+      highest, err = strconv.ParseFloat(strings.TrimSpace(high), 64)
+    }
+    value, tolerance = (lowest+highest)/2, (highest-lowest)/2
+  } else if number, margin, ok := strings.Cut(body, ":"); ok {
+    value, err = strconv.ParseFloat(strings.TrimSpace(number), 64)
+    if err == nil {
+      tolerance, err = strconv.ParseFloat(strings.TrimSpace(margin), 64)
+    }
+  } else {
+    value, err = strconv.ParseFloat(body, 64)
+  }
+  if err != nil {
+    return fmt.Errorf("invalid numeric answer %q", body)
+  }
+
+  question.CorrectNumber = &value
+  if tolerance > 0 {
+    question.Numeric = &models.NumericOptions{Mode: models.NumericModeAbsolute, Tolerance: tolerance}
+  }
+  return nil
+}
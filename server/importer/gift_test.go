@@ -0,0 +1,184 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "reflect"
+  "strings"
+  "testing"
+)
+
+func TestParseGIFTQuestion(t *testing.T) {
+  float := func(value float64) *float64 { return &value }
+
+  tests := []struct {
+    name  string
+    block string
+    want  models.Question
+  }{
+    {
+      name:  "single choice",
+      block: "::Capital:: What is the capital of France? {~Berlin =Paris ~Rome}",
+      want: models.Question{
+        Type:    models.QuestionTypeSingleChoice,
+        Text:    "What is the capital of France?",
+        Options: []string{"Berlin", "Paris", "Rome"},
+        Correct: 1,
+      },
+    },
+    {
+      name:  "feedback and text format are dropped",
+      block: "[markdown]Two plus two? {=4 # right ~5 # too many}",
+      want: models.Question{
+        Type:    models.QuestionTypeSingleChoice,
+        Text:    "Two plus two?",
+        Options: []string{"4", "5"},
+        Correct: 0,
+      },
+    },
+    {
+      name:  "true",
+      block: "The sky is blue. {T}",
+      want:  models.Question{Type: models.QuestionTypeTrueFalse, Text: "The sky is blue.", Correct: 0},
+    },
+    {
+      name:  "false",
+      block: "The sun is cold. {FALSE}",
+      want:  models.Question{Type: models.QuestionTypeTrueFalse, Text: "The sun is cold.", Correct: 1},
+    },
+    {
+      name:  "short answer",
+      block: "Capital of Vietnam? {=Hà Nội =Ha Noi}",
+      want: models.Question{
+        Type:            models.QuestionTypeFreeText,
+        Text:            "Capital of Vietnam?",
+        AcceptedAnswers: []string{"Hà Nội", "Ha Noi"},
+      },
+    },
+    {
+      name:  "weighted multiple answers",
+      block: "Pick the primes {~%50%2 ~%-50%4 ~%50%5}",
+      want: models.Question{
+        Type:       models.QuestionTypeMultiSelect,
+        Text:       "Pick the primes",
+        Options:    []string{"2", "4", "5"},
+        CorrectSet: []int{0, 2},
+      },
+    },
+    {
+      name:  "missing word",
+      block: "The {~cat =dog} barked.",
+      want: models.Question{
+        Type:    models.QuestionTypeSingleChoice,
+        Text:    "The _____ barked.",
+        Options: []string{"cat", "dog"},
+        Correct: 1,
+      },
+    },
+    {
+      name:  "numeric with tolerance",
+      block: "Pick a number near five {#5:1}",
+      want: models.Question{
+        Type:          models.QuestionTypeNumeric,
+        Text:          "Pick a number near five",
+        CorrectNumber: float(5),
+        Numeric:       &models.NumericOptions{Mode: models.NumericModeAbsolute, Tolerance: 1},
+      },
+    },
+    {
+      name:  "numeric range",
+      block: "Pick a number from one to three {#1..3}",
+      want: models.Question{
+        Type:          models.QuestionTypeNumeric,
+        Text:          "Pick a number from one to three",
+        CorrectNumber: float(2),
+        Numeric:       &models.NumericOptions{Mode: models.NumericModeAbsolute, Tolerance: 1},
+      },
+    },
+    {
+      name:  "numeric exact with several answers",
+      block: "Pi to two places? {#=%50%3.1 =3.14}",
+      want: models.Question{
+        Type:          models.QuestionTypeNumeric,
+        Text:          "Pi to two places?",
+        CorrectNumber: float(3.14),
+      },
+    },
+    {
+      name:  "escaped special characters",
+      block: `Which is 1\=1? {=1 \= 1 ~1 \~ 2}`,
+      want: models.Question{
+        Type:    models.QuestionTypeSingleChoice,
+        Text:    "Which is 1=1?",
+        Options: []string{"1 = 1", "1 ~ 2"},
+        Correct: 0,
+      },
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      got, err := parseGIFTQuestion(giftEscapes.Replace(tt.block))
+      if err != nil {
+        t.Fatal(err)
+      }
+      if !reflect.DeepEqual(got, tt.want) {
+        t.Errorf("parseGIFTQuestion() = %+v, want %+v", got, tt.want)
+      }
+    })
+  }
+}
+
+func TestParseGIFTQuestionErrors(t *testing.T) {
+  tests := []struct {
+    name  string
+    block string
+    want  string
+  }{
+    {"essay", "Describe your day. {}", "essay"},
+    {"matching", "Match them {=cat -> meow =dog -> woof}", "matching"},
+    {"no answer block", "Just some text", "no answer block"},
+    {"unterminated title", "::Title What? {T}", "unterminated"},
+    {"no correct answer", "Pick one {~a ~b}", "no correct answer"},
+    {"bad number", "How many? {#lots}", "invalid numeric answer"},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      _, err := parseGIFTQuestion(giftEscapes.Replace(tt.block))
+      if err == nil || !strings.Contains(err.Error(), tt.want) {
+        t.Errorf("parseGIFTQuestion() error = %v, want it to mention %q", err, tt.want)
+      }
+    })
+  }
+}
+
+func TestParseGIFT(t *testing.T) {
+  input := `// Sample quiz
+$CATEGORY: $course$/top/Geography
+
+::Q1:: Capital of France? {~Berlin =Paris}
+
+Describe Paris. {}
+
+$CATEGORY: Science
+The Earth is flat. {F}
+`
+  result, err := Parse(FormatGIFT, []byte(input))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if len(result.Questions) != 2 {
+    t.Fatalf("got %d questions, want 2", len(result.Questions))
+  }
+  if category := result.Questions[0].Category; category != "Geography" {
+    t.Errorf("first category = %q, want Geography", category)
+  }
+  if category := result.Questions[1].Category; category != "Science" {
+    t.Errorf("second category = %q, want Science", category)
+  }
+
+  if len(result.Errors) != 1 || result.Errors[0].Line != 6 {
+    t.Errorf("errors = %v, want the essay question reported on line 6", result.Errors)
+  }
+}
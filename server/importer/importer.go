@@ -0,0 +1,170 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "path/filepath"
+  "strings"
+)
+
+// Format identifies a supported import file format
+type Format string
+
+const (
+  FormatCSV   Format = "csv"
+  FormatJSON  Format = "json"
+  FormatGIFT  Format = "gift"
+  FormatAiken Format = "aiken"
+)
+
+// DefaultPoints is used for imported questions that don't specify points
+const DefaultPoints = 10
+
+// ImportError reports a problem with one part of the input.
+// Line is 1-based and set when the problem can be tied to a line; Question is the
+// 1-based position of the question in the input when it can't.
+type ImportError struct {
+  Line     int    `json:"line,omitempty"`
+  Question int    `json:"question,omitempty"`
+  Message  string `json:"message"`
+}
+
+func (e ImportError) Error() string {
+  if e.Line > 0 {
+    return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+  }
+  if e.Question > 0 {
+    return fmt.Sprintf("question %d: %s", e.Question, e.Message)
+  }
+  return e.Message
+}
+
+// Result holds the questions that parsed successfully and the errors for those that didn't
+type Result struct {
+  Format    Format              `json:"format"`
+  Title     string              `json:"title,omitempty"`
+  Questions []models.Question   `json:"questions"`
+  Rounds    []models.Round      `json:"rounds,omitempty"`
+  Settings  models.QuizSettings `json:"settings"`
+  Errors    []ImportError       `json:"errors"`
+}
+
+// atLine and atQuestion build the position part of an ImportError
+func atLine(line int) ImportError {
+  return ImportError{Line: line}
+}
+
+func atQuestion(number int) ImportError {
+  return ImportError{Question: number}
+}
+
+// addQuestion validates a parsed question and either keeps it or records why it was rejected.
+// Questions without an ID get one from assignIDs once the whole input is parsed.
+func (r *Result) addQuestion(question models.Question, pos ImportError) {
+  if question.Points == 0 {
+    question.Points = DefaultPoints
+  }
+
+  for _, existing := range r.Questions {
+    if question.ID != "" && existing.ID == question.ID {
+      r.addError(pos, fmt.Sprintf("duplicate question id: %s", question.ID))
+      return
+    }
+  }
+
+  question.ApplyDefaults()
+  if err := question.Validate(); err != nil {
+    r.addError(pos, err.Error())
+    return
+  }
+
+  r.Questions = append(r.Questions, question)
+}
+
+// assignIDs gives each question without an ID the first free "q<n>", skipping the IDs the input set
+func (r *Result) assignIDs() {
+  taken := make(map[string]bool, len(r.Questions))
+  for _, question := range r.Questions {
+    taken[question.ID] = true
+  }
+
+  next := 1
+  for i := range r.Questions {
+    if r.Questions[i].ID != "" {
+      continue
+    }
+    for taken[fmt.Sprintf("q%d", next)] {
+      next++
+    }
+    r.Questions[i].ID = fmt.Sprintf("q%d", next)
+    taken[r.Questions[i].ID] = true
+  }
+}
+
+func (r *Result) addError(pos ImportError, message string) {
+  pos.Message = message
+  r.Errors = append(r.Errors, pos)
+}
+
+// DetectFormat infers the import format from a file name extension
+func DetectFormat(filename string) (Format, error) {
+  switch strings.ToLower(filepath.Ext(filename)) {
+  case ".csv":
+    return FormatCSV, nil
+  case ".json":
+    return FormatJSON, nil
+  case ".gift":
+    return FormatGIFT, nil
+  case ".aiken":
+    return FormatAiken, nil
+  }
+  return "", fmt.Errorf("cannot detect format of %q, please specify one of csv, json, gift, aiken", filename)
+}
+
+// Parse parses quiz questions in the given format
+func Parse(format Format, data []byte) (*Result, error) {
+  result := &Result{
+    Format:    format,
+    Questions: []models.Question{},
+    Errors:    []ImportError{},
+  }
+
+  // Strip a UTF-8 byte order mark, which spreadsheet exports commonly add
+  text := strings.TrimPrefix(string(data), "\ufeff")
+
+  switch format {
+  case FormatCSV:
+    parseCSV(text, result)
+  case FormatJSON:
+    parseJSON(text, result)
+  case FormatGIFT:
+    parseGIFT(text, result)
+  case FormatAiken:
+    parseAiken(text, result)
+  default:
+    return nil, fmt.Errorf("unsupported import format: %s", format)
+  }
+  result.assignIDs()
+
+  return result, nil
+}
+
+// splitList splits a "|" separated cell into trimmed, non-empty values
+func splitList(value string) []string {
+  values := make([]string, 0)
+  for _, part := range strings.Split(value, "|") {
+    if part = strings.TrimSpace(part); part != "" {
+      values = append(values, part)
+    }
+  }
+  return values
+}
+
+// letterIndex converts an option letter (A, B, ...) to a 0-based index
+func letterIndex(letter string) (int, bool) {
+  letter = strings.ToUpper(strings.TrimSpace(letter))
+  if len(letter) != 1 || letter[0] < 'A' || letter[0] > 'Z' {
+    return 0, false
+  }
+  return int(letter[0] - 'A'), true
+}
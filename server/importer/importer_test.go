@@ -0,0 +1,100 @@
+package importer
+
+import (
+  "reflect"
+  "testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+  tests := []struct {
+    filename string
+    want     Format
+    wantErr  bool
+  }{
+    {"quiz.csv", FormatCSV, false},
+    {"Quiz.JSON", FormatJSON, false},
+    {"path/to/questions.gift", FormatGIFT, false},
+    {"questions.aiken", FormatAiken, false},
+    {"questions.txt", "", true},
+    {"questions", "", true},
+  }
+
+  for _, tt := range tests {
+    got, err := DetectFormat(tt.filename)
+    if (err != nil) != tt.wantErr || got != tt.want {
+      t.Errorf("DetectFormat(%q) = %q, %v; want %q, error %v", tt.filename, got, err, tt.want, tt.wantErr)
+    }
+  }
+}
+
+func TestParseUnsupportedFormat(t *testing.T) {
+  if _, err := Parse("xlsx", []byte("anything")); err == nil {
+    t.Error("Parse with an unsupported format returned no error")
+  }
+}
+
+func TestParseStripsByteOrderMark(t *testing.T) {
+  result, err := Parse(FormatCSV, []byte("\ufefftext,option_1,option_2,correct\nPick one,A,B,A\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(result.Questions) != 1 || len(result.Errors) != 0 {
+    t.Fatalf("got %d questions and errors %v, want 1 question", len(result.Questions), result.Errors)
+  }
+}
+
+func TestAddQuestionDefaults(t *testing.T) {
+  result, err := Parse(FormatCSV, []byte("id,text,option_1,option_2,correct\nq7,First,A,B,A\nq7,Second,A,B,B\n,Third,A,B,B\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if len(result.Questions) != 2 {
+    t.Fatalf("got %d questions, want 2", len(result.Questions))
+  }
+  if result.Questions[0].Points != DefaultPoints {
+    t.Errorf("points = %d, want default %d", result.Questions[0].Points, DefaultPoints)
+  }
+  if result.Questions[1].ID == "" || result.Questions[1].ID == "q7" {
+    t.Errorf("generated id = %q, want a fresh id", result.Questions[1].ID)
+  }
+
+  if len(result.Errors) != 1 || result.Errors[0].Line != 3 {
+    t.Fatalf("errors = %v, want one duplicate id error on line 3", result.Errors)
+  }
+}
+
+func TestAssignIDsSkipsExplicitIDs(t *testing.T) {
+  result, err := Parse(FormatCSV, []byte("id,text,option_1,option_2,correct\n,First,A,B,A\nq1,Second,A,B,B\n,Third,A,B,A\n"))
+  if err != nil {
+    t.Fatal(err)
+  }
+  if len(result.Errors) != 0 {
+    t.Fatalf("unexpected errors: %v", result.Errors)
+  }
+
+  ids := make([]string, 0)
+  for _, question := range result.Questions {
+    ids = append(ids, question.ID)
+  }
+  if want := []string{"q2", "q1", "q3"}; !reflect.DeepEqual(ids, want) {
+    t.Errorf("ids = %v, want %v", ids, want)
+  }
+}
+
+func TestImportErrorString(t *testing.T) {
+  tests := []struct {
+    err  ImportError
+    want string
+  }{
+    {ImportError{Line: 4, Message: "bad"}, "line 4: bad"},
+    {ImportError{Question: 2, Message: "bad"}, "question 2: bad"},
+    {ImportError{Message: "bad"}, "bad"},
+  }
+
+  for _, tt := range tests {
+    if got := tt.err.Error(); got != tt.want {
+      t.Errorf("Error() = %q, want %q", got, tt.want)
+    }
+  }
+}
@@ -0,0 +1,65 @@
+package importer
+
+import (
+  "btaskee-quiz/models"
+  "encoding/json"
+  "fmt"
+  "strings"
+)
+
+// parseJSON parses the native JSON form of models.Quiz (title, questions, rounds and settings),
+// or a bare array of questions. The self-paced open/close window of an exported quiz is dropped
+// since its dates belong to the original run.
+func parseJSON(text string, result *Result) {
+  var rawQuestions []json.RawMessage
+
+  trimmed := strings.TrimSpace(text)
+  if strings.HasPrefix(trimmed, "[") {
+    if err := json.Unmarshal([]byte(text), &rawQuestions); err != nil {
+      result.addError(jsonErrorPosition(text, err), err.Error())
+      return
+    }
+  } else {
+    var quiz struct {
+      Title     string              `json:"title"`
+      Questions []json.RawMessage   `json:"questions"`
+      Rounds    []models.Round      `json:"rounds"`
+      Settings  models.QuizSettings `json:"settings"`
+    }
+    if err := json.Unmarshal([]byte(text), &quiz); err != nil {
+      result.addError(jsonErrorPosition(text, err), err.Error())
+      return
+    }
+    result.Title = quiz.Title
+    result.Rounds = quiz.Rounds
+    result.Settings = quiz.Settings.WithoutWindow()
+    rawQuestions = quiz.Questions
+  }
+
+  for i, raw := range rawQuestions {
+    var question models.Question
+    if err := json.Unmarshal(raw, &question); err != nil {
+      result.addError(atQuestion(i+1), fmt.Sprintf("invalid question: %v", err))
+      continue
+    }
+    result.addQuestion(question, atQuestion(i+1))
+  }
+}
+
+// jsonErrorPosition maps a decoding error offset to a line number when available
+func jsonErrorPosition(text string, err error) ImportError {
+  var offset int64
+  switch e := err.(type) {
+  case *json.SyntaxError:
+    offset = e.Offset
+  case *json.UnmarshalTypeError:
+    offset = e.Offset
+  default:
+    return ImportError{}
+  }
+
+  if offset > int64(len(text)) {
+    offset = int64(len(text))
+  }
+  return atLine(strings.Count(text[:offset], "\n") + 1)
+}
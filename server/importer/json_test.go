@@ -0,0 +1,112 @@
+package importer
+
+import "testing"
+
+func TestParseJSON(t *testing.T) {
+  tests := []struct {
+    name          string
+    input         string
+    wantTitle     string
+    wantQuestions int
+    wantErrors    []ImportError
+  }{
+    {
+      name:          "quiz object",
+      input:         `{"title": "Trivia", "questions": [{"id": "q1", "text": "Pick", "options": ["a", "b"], "correct": 1}]}`,
+      wantTitle:     "Trivia",
+      wantQuestions: 1,
+    },
+    {
+      name:          "bare array",
+      input:         `[{"text": "Pick", "options": ["a", "b"], "correct": 0}, {"text": "Again", "options": ["a", "b"], "correct": 1}]`,
+      wantQuestions: 2,
+    },
+    {
+      name:          "invalid question is reported by position",
+      input:         `[{"text": "Pick", "options": ["a", "b"], "correct": 0}, {"text": "Bad", "options": ["a", "b"], "correct": 5}]`,
+      wantQuestions: 1,
+      wantErrors:    []ImportError{{Question: 2}},
+    },
+    {
+      name:          "wrong field type is reported by position",
+      input:         `[{"text": "Pick", "options": "a", "correct": 0}]`,
+      wantQuestions: 0,
+      wantErrors:    []ImportError{{Question: 1}},
+    },
+    {
+      name:          "syntax error is reported by line",
+      input:         "{\n  \"title\": \"Trivia\",\n  \"questions\": [,]\n}",
+      wantQuestions: 0,
+      wantErrors:    []ImportError{{Line: 3}},
+    },
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      result, err := Parse(FormatJSON, []byte(tt.input))
+      if err != nil {
+        t.Fatal(err)
+      }
+      if result.Title != tt.wantTitle {
+        t.Errorf("title = %q, want %q", result.Title, tt.wantTitle)
+      }
+      if len(result.Questions) != tt.wantQuestions {
+        t.Errorf("got %d questions, want %d", len(result.Questions), tt.wantQuestions)
+      }
+      if len(result.Errors) != len(tt.wantErrors) {
+        t.Fatalf("errors = %v, want %d", result.Errors, len(tt.wantErrors))
+      }
+      for i, want := range tt.wantErrors {
+        got := result.Errors[i]
+        if got.Line != want.Line || got.Question != want.Question {
+          t.Errorf("error %d at line %d question %d, want line %d question %d", i, got.Line, got.Question, want.Line, want.Question)
+        }
+      }
+    })
+  }
+}
+
+func TestParseJSONSettings(t *testing.T) {
+  input := `{
+    "title": "Onboarding",
+    "questions": [{"id": "q1", "text": "Pick", "options": ["a", "b"], "correct": 0}],
+    "settings": {"shuffle_options": true, "mode": "self_paced", "opens_at": "2024-01-01T00:00:00Z", "closes_at": "2024-01-08T00:00:00Z"}
+  }`
+  result, err := Parse(FormatJSON, []byte(input))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  settings := result.Settings
+  if !settings.ShuffleOptions || !settings.IsSelfPaced() {
+    t.Errorf("settings = %+v, want shuffled options in self-paced mode", settings)
+  }
+  if settings.OpensAt != nil || settings.ClosesAt != nil {
+    t.Errorf("window = %v..%v, want it dropped", settings.OpensAt, settings.ClosesAt)
+  }
+}
+
+func TestParseJSONRounds(t *testing.T) {
+  input := `{
+    "title": "Trivia",
+    "questions": [
+      {"id": "q1", "text": "Pick", "options": ["a", "b"], "correct": 0},
+      {"id": "q2", "text": "Again", "options": ["a", "b"], "correct": 1}
+    ],
+    "rounds": [
+      {"title": "Warm-up", "question_ids": ["q1"]},
+      {"title": "Final", "question_ids": ["q2"], "multiplier": 2}
+    ]
+  }`
+  result, err := Parse(FormatJSON, []byte(input))
+  if err != nil {
+    t.Fatal(err)
+  }
+
+  if len(result.Rounds) != 2 {
+    t.Fatalf("got %d rounds, want 2", len(result.Rounds))
+  }
+  if round := result.Rounds[1]; round.Title != "Final" || round.Multiplier != 2 || len(round.QuestionIDs) != 1 {
+    t.Errorf("second round = %+v", round)
+  }
+}
@@ -12,6 +12,11 @@ import (
 )
 
 func main() {
+  // go run . import <file> - import a quiz file from the command line
+  if len(os.Args) > 1 && os.Args[1] == "import" {
+    os.Exit(runImportCommand(os.Args[2:]))
+  }
+
   log.Printf("Starting Btaskee Real-Time Quiz with Redis...")
 
   // Initialize Redis service
@@ -31,7 +36,7 @@ func main() {
   config := cors.DefaultConfig()
   config.AllowAllOrigins = true
   config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-  config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-User-ID"}
+  config.AllowHeaders = []string{"Origin", "Content-Type", "Accept", "Authorization", "X-User-ID", "X-Host-Token"}
   router.Use(cors.New(config))
 
   // Web interface route
@@ -51,12 +56,24 @@ func main() {
     // GET /api/v1/quizzes - Get all active quizzes
     api.GET("/quizzes", httpHandler.GetActiveQuizzes)
 
+    // GET /api/v1/quizzes/archived - List ended quizzes (?offset=&limit=)
+    api.GET("/quizzes/archived", httpHandler.GetArchivedQuizzes)
+
     // GET /api/v1/quizzes/:id - Get quiz details
     api.GET("/quizzes/:id", httpHandler.GetQuiz)
 
     // DELETE /api/v1/quizzes/:id - Delete a quiz
     api.DELETE("/quizzes/:id", httpHandler.DeleteQuiz)
 
+    // POST /api/v1/quizzes/import - Import a quiz from a CSV, JSON, GIFT or Aiken file
+    api.POST("/quizzes/import", httpHandler.ImportQuiz)
+
+    // POST /api/v1/quizzes/:id/clone - Clone a quiz into a fresh waiting quiz (host only)
+    api.POST("/quizzes/:id/clone", httpHandler.CloneQuiz)
+
+    // POST /api/v1/quizzes/:id/template - Save a quiz as a template (host only)
+    api.POST("/quizzes/:id/template", httpHandler.SaveQuizTemplate)
+
     // Quiz participation
     // POST /api/v1/quizzes/join - Join a quiz
     api.POST("/quizzes/join", httpHandler.JoinQuiz)
@@ -64,16 +81,103 @@ func main() {
     // POST /api/v1/quizzes/answer - Submit an answer
     api.POST("/quizzes/answer", httpHandler.SubmitAnswer)
 
-    // GET /api/v1/quizzes/:id/leaderboard - Get leaderboard
+    // POST /api/v1/quizzes/:id/attempt/start - Start a self-paced attempt (X-User-ID)
+    api.POST("/quizzes/:id/attempt/start", httpHandler.StartAttempt)
+
+    // POST /api/v1/quizzes/:id/attempt/finish - Finish a self-paced attempt early (X-User-ID)
+    api.POST("/quizzes/:id/attempt/finish", httpHandler.FinishAttempt)
+
+    // GET /api/v1/quizzes/:id/attempt - Get self-paced attempt progress (X-User-ID)
+    api.GET("/quizzes/:id/attempt", httpHandler.GetAttempt)
+
+    // GET /api/v1/quizzes/:id/leaderboard - Get leaderboard (?offset=&limit=)
     api.GET("/quizzes/:id/leaderboard", httpHandler.GetLeaderboard)
 
+    // GET /api/v1/quizzes/:id/leaderboard/around/:userId - Get a user's part of the leaderboard (?radius=)
+    api.GET("/quizzes/:id/leaderboard/around/:userId", httpHandler.GetLeaderboardAround)
+
+    // GET /api/v1/quizzes/:id/results - Export results (?format=csv|json)
+    api.GET("/quizzes/:id/results", httpHandler.GetResults)
+
     // Quiz control
-    // POST /api/v1/quizzes/:id/start - Start a quiz
+    // POST /api/v1/quizzes/:id/start - Start a quiz (host only)
     api.POST("/quizzes/:id/start", httpHandler.StartQuiz)
 
-    // POST /api/v1/quizzes/:id/end - End a quiz
+    // POST /api/v1/quizzes/:id/end - End a quiz (host only)
     api.POST("/quizzes/:id/end", httpHandler.EndQuiz)
 
+    // POST /api/v1/quizzes/:id/next - Close the current question and show the next one (host only)
+    api.POST("/quizzes/:id/next", httpHandler.NextQuestion)
+
+    // POST /api/v1/quizzes/:id/previous - Show the previous question again (host only)
+    api.POST("/quizzes/:id/previous", httpHandler.PreviousQuestion)
+
+    // POST /api/v1/quizzes/:id/rounds/next - End the intermission and start the next round (host only)
+    api.POST("/quizzes/:id/rounds/next", httpHandler.NextRound)
+
+    // POST /api/v1/quizzes/:id/pause - Pause a running quiz (host only)
+    api.POST("/quizzes/:id/pause", httpHandler.PauseQuiz)
+
+    // POST /api/v1/quizzes/:id/resume - Resume a paused quiz (host only)
+    api.POST("/quizzes/:id/resume", httpHandler.ResumeQuiz)
+
+    // POST /api/v1/quizzes/:id/reset - Take an ended quiz back to waiting (host only)
+    api.POST("/quizzes/:id/reset", httpHandler.ResetQuiz)
+
+    // POST /api/v1/quizzes/:id/lock - Lock the lobby (host only)
+    api.POST("/quizzes/:id/lock", httpHandler.LockQuiz)
+
+    // POST /api/v1/quizzes/:id/unlock - Unlock the lobby (host only)
+    api.POST("/quizzes/:id/unlock", httpHandler.UnlockQuiz)
+
+    // Question editing (host only, while the quiz is waiting)
+    // POST /api/v1/quizzes/:id/questions - Add a question
+    api.POST("/quizzes/:id/questions", httpHandler.AddQuizQuestion)
+
+    // PUT /api/v1/quizzes/:id/questions/order - Reorder questions
+    api.PUT("/quizzes/:id/questions/order", httpHandler.ReorderQuizQuestions)
+
+    // PUT /api/v1/quizzes/:id/questions/:questionId - Update a question
+    api.PUT("/quizzes/:id/questions/:questionId", httpHandler.UpdateQuizQuestion)
+
+    // DELETE /api/v1/quizzes/:id/questions/:questionId - Delete a question
+    api.DELETE("/quizzes/:id/questions/:questionId", httpHandler.DeleteQuizQuestion)
+
+    // POST /api/v1/quizzes/:id/questions/:questionId/close - Close a question and score deferred answers (host only)
+    api.POST("/quizzes/:id/questions/:questionId/close", httpHandler.CloseQuestion)
+
+    // Question bank
+    // POST /api/v1/questions - Add a question to the bank
+    api.POST("/questions", httpHandler.CreateQuestion)
+
+    // GET /api/v1/questions - List bank questions (filter by ?category= and ?tag=; answers for admins only)
+    api.GET("/questions", httpHandler.ListQuestions)
+
+    // GET /api/v1/questions/:id - Get a bank question (answers for admins only)
+    api.GET("/questions/:id", httpHandler.GetQuestion)
+
+    // PUT /api/v1/questions/:id - Update a bank question (admin only)
+    api.PUT("/questions/:id", httpHandler.UpdateQuestion)
+
+    // DELETE /api/v1/questions/:id - Delete a bank question (admin only)
+    api.DELETE("/questions/:id", httpHandler.DeleteQuestion)
+
+    // Quiz templates
+    // POST /api/v1/templates - Save a quiz definition as a template
+    api.POST("/templates", httpHandler.CreateTemplate)
+
+    // GET /api/v1/templates - List templates (admin only)
+    api.GET("/templates", httpHandler.ListTemplates)
+
+    // GET /api/v1/templates/:id - Get a template (admin only)
+    api.GET("/templates/:id", httpHandler.GetTemplate)
+
+    // DELETE /api/v1/templates/:id - Delete a template (admin only)
+    api.DELETE("/templates/:id", httpHandler.DeleteTemplate)
+
+    // POST /api/v1/templates/:id/quizzes - Create a new quiz from a template
+    api.POST("/templates/:id/quizzes", httpHandler.InstantiateTemplate)
+
     // Health check
     // GET /api/v1/health - Health check endpoint
     api.GET("/health", httpHandler.HealthCheck)
@@ -0,0 +1,215 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// QuizMode decides who drives the quiz: the host (live) or each participant (self-paced)
+type QuizMode string
+
+const (
+	QuizModeLive      QuizMode = "live"
+	QuizModeSelfPaced QuizMode = "self_paced"
+)
+
+// GetMode returns the quiz mode, defaulting to live
+func (s QuizSettings) GetMode() QuizMode {
+	if s.Mode == "" {
+		return QuizModeLive
+	}
+	return s.Mode
+}
+
+// IsSelfPaced reports whether participants take the quiz on their own clock
+func (s QuizSettings) IsSelfPaced() bool {
+	return s.GetMode() == QuizModeSelfPaced
+}
+
+// IsOpenAt reports whether t falls inside the quiz's open/close window
+func (s QuizSettings) IsOpenAt(t time.Time) bool {
+	if s.OpensAt != nil && t.Before(*s.OpensAt) {
+		return false
+	}
+	if s.ClosesAt != nil && !t.Before(*s.ClosesAt) {
+		return false
+	}
+	return true
+}
+
+// WithoutWindow returns the settings without their open/close window. Quizzes copied from another
+// quiz or a template drop it, since the original's dates would already have passed.
+func (s QuizSettings) WithoutWindow() QuizSettings {
+	s.OpensAt, s.ClosesAt = nil, nil
+	return s
+}
+
+// validateMode checks the mode-specific settings
+func (s QuizSettings) validateMode() error {
+	switch s.GetMode() {
+	case QuizModeLive, QuizModeSelfPaced:
+	default:
+		return fmt.Errorf("unknown mode: %s", s.Mode)
+	}
+	if s.AttemptTimeLimit < 0 {
+		return fmt.Errorf("attempt time limit must not be negative")
+	}
+	if s.OpensAt != nil && s.ClosesAt != nil && !s.ClosesAt.After(*s.OpensAt) {
+		return fmt.Errorf("closes_at must be after opens_at")
+	}
+	return nil
+}
+
+// AttemptState is a participant's progress through a self-paced quiz
+type AttemptState struct {
+	StartedAt     *time.Time           `json:"started_at,omitempty"`
+	Deadline      *time.Time           `json:"deadline,omitempty"`
+	FinishedAt    *time.Time           `json:"finished_at,omitempty"`
+	Cursor        int                  `json:"cursor"`
+	QuestionCount int                  `json:"question_count"`
+	Question      *ParticipantQuestion `json:"question,omitempty"`
+	Complete      bool                 `json:"complete"`
+}
+
+// StartAttempt starts the user's personal clock. The deadline is the attempt time limit
+// or the end of the quiz window, whichever comes first.
+func (u *User) StartAttempt(now time.Time, settings QuizSettings) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.AttemptStartedAt != nil {
+		return fmt.Errorf("attempt already started")
+	}
+
+	u.AttemptStartedAt = &now
+	u.Cursor = 0
+	u.CursorAt = &now
+	if settings.AttemptTimeLimit > 0 {
+		deadline := now.Add(time.Duration(settings.AttemptTimeLimit) * time.Second)
+		u.AttemptDeadline = &deadline
+	}
+	if settings.ClosesAt != nil && (u.AttemptDeadline == nil || settings.ClosesAt.Before(*u.AttemptDeadline)) {
+		deadline := *settings.ClosesAt
+		u.AttemptDeadline = &deadline
+	}
+	return nil
+}
+
+// AttemptQuestion returns the question at the user's cursor, in their own question order,
+// or nil when the attempt has not started or is over
+func (u *User) AttemptQuestion(questions []Question) *Question {
+	ordered := u.OrderedQuestions(questions)
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if u.AttemptStartedAt == nil || u.AttemptFinishedAt != nil || u.Cursor >= len(ordered) {
+		return nil
+	}
+	return ordered[u.Cursor]
+}
+
+// AdvanceAttempt moves the cursor past an answered question, finishing the attempt after the last one
+func (u *User) AdvanceAttempt(now time.Time, questionCount int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.Cursor++
+	u.CursorAt = &now
+	if u.Cursor >= questionCount && u.AttemptFinishedAt == nil {
+		u.AttemptFinishedAt = &now
+	}
+}
+
+// FinishAttempt ends the attempt early; unanswered questions score nothing
+func (u *User) FinishAttempt(now time.Time) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.AttemptStartedAt == nil {
+		return fmt.Errorf("attempt not started")
+	}
+	if u.AttemptFinishedAt != nil {
+		return fmt.Errorf("attempt already finished")
+	}
+	u.AttemptFinishedAt = &now
+	return nil
+}
+
+// shiftAttempt gives a running attempt back the time a pause took from it: its deadline (never
+// past the quiz's closing time) and its current question's clock move back by the pause.
+// Attempts that were over when the quiz was paused are left alone.
+func (u *User) shiftAttempt(pausedAt time.Time, paused time.Duration, closesAt *time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.AttemptStartedAt == nil || u.AttemptFinishedAt != nil {
+		return
+	}
+	if u.AttemptDeadline != nil {
+		if !u.AttemptDeadline.After(pausedAt) {
+			return
+		}
+		deadline := u.AttemptDeadline.Add(paused)
+		if closesAt != nil && closesAt.Before(deadline) {
+			deadline = *closesAt
+		}
+		u.AttemptDeadline = &deadline
+	}
+	if u.CursorAt != nil {
+		cursorAt := u.CursorAt.Add(paused)
+		u.CursorAt = &cursorAt
+	}
+}
+
+// IsAttemptExpired reports whether t is past the user's deadline plus grace
+func (u *User) IsAttemptExpired(t time.Time, grace time.Duration) bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	return u.AttemptDeadline != nil && t.After(u.AttemptDeadline.Add(grace))
+}
+
+// ExpireAttempt ends a running attempt whose time (plus grace) is up, recording its deadline as
+// the finish time. It returns false if the attempt is not running or still has time left.
+func (u *User) ExpireAttempt(now time.Time, grace time.Duration) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.AttemptStartedAt == nil || u.AttemptFinishedAt != nil || u.AttemptDeadline == nil {
+		return false
+	}
+	if !now.After(u.AttemptDeadline.Add(grace)) {
+		return false
+	}
+	finishedAt := *u.AttemptDeadline
+	u.AttemptFinishedAt = &finishedAt
+	return true
+}
+
+// AttemptComplete reports whether the attempt is over: finished, or out of time
+func (u *User) AttemptComplete(now time.Time) bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	if u.AttemptFinishedAt != nil {
+		return true
+	}
+	return u.AttemptDeadline != nil && now.After(*u.AttemptDeadline)
+}
+
+// AttemptState returns the user's progress with their current question as they see it
+func (q *Quiz) AttemptState(user *User) AttemptState {
+	question := user.AttemptQuestion(q.Questions)
+
+	user.mu.RLock()
+	state := AttemptState{
+		StartedAt:     user.AttemptStartedAt,
+		Deadline:      user.AttemptDeadline,
+		FinishedAt:    user.AttemptFinishedAt,
+		Cursor:        user.Cursor,
+		QuestionCount: len(q.Questions),
+	}
+	user.mu.RUnlock()
+
+	state.Complete = user.AttemptComplete(time.Now())
+	if question != nil && !state.Complete {
+		view := question.ParticipantView(user.OptionOrder(question))
+		state.Question = &view
+	}
+	return state
+}
@@ -0,0 +1,207 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartAttempt(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		settings     QuizSettings
+		wantDeadline *time.Time
+	}{
+		{"unlimited", QuizSettings{}, nil},
+		{"attempt time limit", QuizSettings{AttemptTimeLimit: 600}, timePtr(now.Add(10 * time.Minute))},
+		{"quiz closes first", QuizSettings{AttemptTimeLimit: 600, ClosesAt: timePtr(now.Add(5 * time.Minute))}, timePtr(now.Add(5 * time.Minute))},
+		{"time limit ends first", QuizSettings{AttemptTimeLimit: 60, ClosesAt: timePtr(now.Add(5 * time.Minute))}, timePtr(now.Add(time.Minute))},
+		{"closing time only", QuizSettings{ClosesAt: timePtr(now.Add(time.Hour))}, timePtr(now.Add(time.Hour))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1"}
+			if err := user.StartAttempt(now, tt.settings); err != nil {
+				t.Fatalf("StartAttempt() error = %v", err)
+			}
+			if !equalTimes(user.AttemptDeadline, tt.wantDeadline) {
+				t.Errorf("deadline = %v, want %v", user.AttemptDeadline, tt.wantDeadline)
+			}
+			if err := user.StartAttempt(now, tt.settings); err == nil {
+				t.Error("starting a second attempt succeeded")
+			}
+		})
+	}
+}
+
+func TestShiftAttempt(t *testing.T) {
+	started := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	pausedAt := started.Add(time.Minute)
+	paused := 2 * time.Minute
+
+	tests := []struct {
+		name         string
+		deadline     *time.Time
+		finished     bool
+		closesAt     *time.Time
+		wantDeadline *time.Time
+		wantCursorAt time.Time
+	}{
+		{
+			name:         "deadline moves back",
+			deadline:     timePtr(started.Add(5 * time.Minute)),
+			wantDeadline: timePtr(started.Add(7 * time.Minute)),
+			wantCursorAt: started.Add(2 * time.Minute),
+		},
+		{
+			name:         "never past the closing time",
+			deadline:     timePtr(started.Add(5 * time.Minute)),
+			closesAt:     timePtr(started.Add(6 * time.Minute)),
+			wantDeadline: timePtr(started.Add(6 * time.Minute)),
+			wantCursorAt: started.Add(2 * time.Minute),
+		},
+		{
+			name:         "unlimited attempt",
+			wantCursorAt: started.Add(2 * time.Minute),
+		},
+		{
+			name:         "out of time before the pause",
+			deadline:     timePtr(started.Add(30 * time.Second)),
+			wantDeadline: timePtr(started.Add(30 * time.Second)),
+			wantCursorAt: started,
+		},
+		{
+			name:         "finished attempt",
+			deadline:     timePtr(started.Add(5 * time.Minute)),
+			finished:     true,
+			wantDeadline: timePtr(started.Add(5 * time.Minute)),
+			wantCursorAt: started,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1", AttemptStartedAt: &started, AttemptDeadline: tt.deadline, CursorAt: timePtr(started)}
+			if tt.finished {
+				user.AttemptFinishedAt = timePtr(started.Add(10 * time.Second))
+			}
+
+			user.shiftAttempt(pausedAt, paused, tt.closesAt)
+
+			if !equalTimes(user.AttemptDeadline, tt.wantDeadline) {
+				t.Errorf("deadline = %v, want %v", user.AttemptDeadline, tt.wantDeadline)
+			}
+			if !user.CursorAt.Equal(tt.wantCursorAt) {
+				t.Errorf("cursor at = %v, want %v", user.CursorAt, tt.wantCursorAt)
+			}
+		})
+	}
+}
+
+func TestExpireAttempt(t *testing.T) {
+	started := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadline := started.Add(time.Minute)
+	grace := 2 * time.Second
+
+	tests := []struct {
+		name         string
+		started      bool
+		deadline     *time.Time
+		finished     bool
+		now          time.Time
+		want         bool
+		wantComplete bool
+	}{
+		{"not started", false, nil, false, deadline.Add(time.Hour), false, false},
+		{"unlimited", true, nil, false, deadline.Add(time.Hour), false, false},
+		{"time left", true, &deadline, false, deadline.Add(-time.Second), false, false},
+		{"within grace", true, &deadline, false, deadline.Add(grace), false, true},
+		{"out of time", true, &deadline, false, deadline.Add(grace + time.Second), true, true},
+		{"already finished", true, &deadline, true, deadline.Add(time.Hour), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1", AttemptDeadline: tt.deadline}
+			if tt.started {
+				user.AttemptStartedAt = &started
+			}
+			if tt.finished {
+				user.AttemptFinishedAt = timePtr(started.Add(time.Second))
+			}
+
+			if got := user.AttemptComplete(tt.now); got != tt.wantComplete {
+				t.Errorf("AttemptComplete() = %v, want %v", got, tt.wantComplete)
+			}
+			if got := user.ExpireAttempt(tt.now, grace); got != tt.want {
+				t.Fatalf("ExpireAttempt() = %v, want %v", got, tt.want)
+			}
+			if tt.want && !user.AttemptFinishedAt.Equal(deadline) {
+				t.Errorf("finished at = %v, want the deadline %v", user.AttemptFinishedAt, deadline)
+			}
+		})
+	}
+}
+
+func TestIsOpenAt(t *testing.T) {
+	opens := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	closes := opens.Add(8 * time.Hour)
+	window := QuizSettings{OpensAt: &opens, ClosesAt: &closes}
+
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		at       time.Time
+		want     bool
+	}{
+		{"no window", QuizSettings{}, opens, true},
+		{"before opening", window, opens.Add(-time.Second), false},
+		{"at opening", window, opens, true},
+		{"inside", window, opens.Add(time.Hour), true},
+		{"at closing", window, closes, false},
+		{"open-ended", QuizSettings{OpensAt: &opens}, closes.Add(time.Hour), true},
+		{"without window", window.WithoutWindow(), closes.Add(time.Hour), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.settings.IsOpenAt(tt.at); got != tt.want {
+				t.Errorf("IsOpenAt(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMode(t *testing.T) {
+	opens := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		wantErr  bool
+	}{
+		{"live by default", QuizSettings{}, false},
+		{"self-paced", QuizSettings{Mode: QuizModeSelfPaced, AttemptTimeLimit: 600}, false},
+		{"unknown mode", QuizSettings{Mode: "async"}, true},
+		{"negative attempt limit", QuizSettings{Mode: QuizModeSelfPaced, AttemptTimeLimit: -1}, true},
+		{"closes after opening", QuizSettings{OpensAt: &opens, ClosesAt: timePtr(opens.Add(time.Hour))}, false},
+		{"closes at opening", QuizSettings{OpensAt: &opens, ClosesAt: &opens}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.validateMode(); (err != nil) != tt.wantErr {
+				t.Errorf("validateMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LateJoinPolicy decides whether users may join a quiz that has already started
+type LateJoinPolicy string
+
+const (
+	LateJoinAllow LateJoinPolicy = "allow"
+	LateJoinDeny  LateJoinPolicy = "deny"
+	// LateJoinNoPoints admits late users and lets them answer questions closed before they joined, for no points
+	LateJoinNoPoints LateJoinPolicy = "allow_without_points"
+)
+
+// Lobby errors returned by Admit
+var (
+	ErrLobbyLocked    = errors.New("the lobby is locked")
+	ErrQuizFull       = errors.New("the quiz is full")
+	ErrLateJoinDenied = errors.New("the quiz has already started")
+)
+
+// GetLateJoin returns the late-join policy, defaulting to allow
+func (s QuizSettings) GetLateJoin() LateJoinPolicy {
+	if s.LateJoin == "" {
+		return LateJoinAllow
+	}
+	return s.LateJoin
+}
+
+// Validate checks the settings chosen by the host
+func (s QuizSettings) Validate() error {
+	if s.TimeLimit < 0 {
+		return fmt.Errorf("time limit must not be negative")
+	}
+	if s.MaxParticipants < 0 {
+		return fmt.Errorf("max participants must not be negative")
+	}
+	switch s.GetLateJoin() {
+	case LateJoinAllow, LateJoinDeny, LateJoinNoPoints:
+	default:
+		return fmt.Errorf("unknown late join policy: %s", s.LateJoin)
+	}
+	if err := s.validateMode(); err != nil {
+		return err
+	}
+	if err := s.validateProgression(); err != nil {
+		return err
+	}
+	return s.validateScoring()
+}
+
+// Admit adds a user to the quiz if the lobby lock, participant cap and late-join policy allow it
+func (q *Quiz) Admit(user *User) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.Locked {
+		return ErrLobbyLocked
+	}
+	if q.Settings.MaxParticipants > 0 && len(q.Participants) >= q.Settings.MaxParticipants {
+		return ErrQuizFull
+	}
+
+	if q.Status != QuizStatusWaiting {
+		switch q.Settings.GetLateJoin() {
+		case LateJoinDeny:
+			return ErrLateJoinDenied
+		case LateJoinNoPoints:
+			for i := range q.Questions {
+				if q.ClosedQuestions[q.Questions[i].ID] {
+					user.LateQuestions = append(user.LateQuestions, q.Questions[i].ID)
+				}
+			}
+		}
+	}
+
+	q.Participants[user.ID] = user
+	return nil
+}
+
+// SetLocked locks or unlocks the lobby; it returns false if it was already in that state
+func (q *Quiz) SetLocked(locked bool) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.Locked == locked {
+		return false
+	}
+	q.Locked = locked
+	return true
+}
+
+// IsLateQuestion reports whether a question was closed before the user joined
+func (u *User) IsLateQuestion(questionID string) bool {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for _, id := range u.LateQuestions {
+		if id == questionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAdmit(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   QuizStatus
+		settings QuizSettings
+		locked   bool
+		joined   int
+		wantErr  error
+		wantLate []string
+	}{
+		{"open lobby", QuizStatusWaiting, QuizSettings{}, false, 0, nil, nil},
+		{"locked lobby", QuizStatusWaiting, QuizSettings{}, true, 0, ErrLobbyLocked, nil},
+		{"below the cap", QuizStatusWaiting, QuizSettings{MaxParticipants: 2}, false, 1, nil, nil},
+		{"at the cap", QuizStatusWaiting, QuizSettings{MaxParticipants: 2}, false, 2, ErrQuizFull, nil},
+		{"late join allowed by default", QuizStatusActive, QuizSettings{}, false, 0, nil, nil},
+		{"late join denied", QuizStatusActive, QuizSettings{LateJoin: LateJoinDeny}, false, 0, ErrLateJoinDenied, nil},
+		{"deny only applies once started", QuizStatusWaiting, QuizSettings{LateJoin: LateJoinDeny}, false, 0, nil, nil},
+		{"late join without points", QuizStatusActive, QuizSettings{LateJoin: LateJoinNoPoints}, false, 0, nil, []string{"q1", "q3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{
+				Status:          tt.status,
+				Settings:        tt.settings,
+				Locked:          tt.locked,
+				Questions:       []Question{{ID: "q1"}, {ID: "q2"}, {ID: "q3"}},
+				ClosedQuestions: map[string]bool{"q1": true, "q3": true},
+				Participants:    make(map[string]*User),
+			}
+			for i := 0; i < tt.joined; i++ {
+				id := string(rune('a' + i))
+				quiz.Participants[id] = &User{ID: id}
+			}
+
+			user := &User{ID: "new"}
+			err := quiz.Admit(user)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Admit() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, joined := quiz.Participants[user.ID]; joined != (tt.wantErr == nil) {
+				t.Errorf("joined = %v, want %v", joined, tt.wantErr == nil)
+			}
+			if !reflect.DeepEqual(user.LateQuestions, tt.wantLate) {
+				t.Errorf("late questions = %v, want %v", user.LateQuestions, tt.wantLate)
+			}
+		})
+	}
+}
+
+func TestValidateLobbySettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		wantErr  bool
+	}{
+		{"defaults", QuizSettings{}, false},
+		{"negative time limit", QuizSettings{TimeLimit: -1}, true},
+		{"negative participant cap", QuizSettings{MaxParticipants: -1}, true},
+		{"known late join policy", QuizSettings{LateJoin: LateJoinNoPoints}, false},
+		{"unknown late join policy", QuizSettings{LateJoin: "sometimes"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// NumericMode represents how numeric answers are scored
+type NumericMode string
+
+const (
+	NumericModeExact    NumericMode = "exact"
+	NumericModeAbsolute NumericMode = "absolute"
+	NumericModePercent  NumericMode = "percent"
+	NumericModeClosest  NumericMode = "closest"
+)
+
+// NumericOptions configures scoring of numeric/estimation questions.
+// Tolerance is an absolute difference in absolute mode and a percentage of the correct value in percent mode.
+type NumericOptions struct {
+	Mode      NumericMode `json:"mode"`
+	Tolerance float64     `json:"tolerance,omitempty"`
+}
+
+// GetNumeric returns the numeric scoring options, defaulting to exact match
+func (q *Question) GetNumeric() NumericOptions {
+	if q.Numeric == nil || q.Numeric.Mode == "" {
+		return NumericOptions{Mode: NumericModeExact}
+	}
+	return *q.Numeric
+}
+
+// IsDeferred reports whether answers to the question are scored only when the question closes
+func (q *Question) IsDeferred() bool {
+	return q.GetType() == QuestionTypeNumeric && q.GetNumeric().Mode == NumericModeClosest
+}
+
+// validateNumeric checks the numeric scoring configuration
+func (q *Question) validateNumeric() error {
+	if q.CorrectNumber == nil {
+		return fmt.Errorf("question %s: correct_number is required", q.ID)
+	}
+
+	options := q.GetNumeric()
+	switch options.Mode {
+	case NumericModeExact, NumericModeClosest:
+	case NumericModeAbsolute, NumericModePercent:
+		if options.Tolerance < 0 {
+			return fmt.Errorf("question %s: tolerance must not be negative", q.ID)
+		}
+	default:
+		return fmt.Errorf("question %s: unknown numeric mode %q", q.ID, options.Mode)
+	}
+	return nil
+}
+
+// numberMatches checks a number against the correct value for the non-deferred modes
+func (q *Question) numberMatches(number float64) bool {
+	options := q.GetNumeric()
+	diff := math.Abs(number - *q.CorrectNumber)
+
+	switch options.Mode {
+	case NumericModeExact:
+		return diff == 0
+	case NumericModeAbsolute:
+		return diff <= options.Tolerance
+	case NumericModePercent:
+		return diff <= math.Abs(*q.CorrectNumber)*options.Tolerance/100
+	}
+	return false
+}
+
+// ClosestScores awards points for a closest-wins question given each participant's answer.
+// The closest participants get full points, the farthest get none, and everyone in between
+// is scaled linearly by distance.
+func (q *Question) ClosestScores(numbers map[string]float64) map[string]int {
+	scores := make(map[string]int, len(numbers))
+	if len(numbers) == 0 {
+		return scores
+	}
+
+	best, worst := math.Inf(1), 0.0
+	for _, number := range numbers {
+		diff := math.Abs(number - *q.CorrectNumber)
+		best = math.Min(best, diff)
+		worst = math.Max(worst, diff)
+	}
+
+	for userID, number := range numbers {
+		diff := math.Abs(number - *q.CorrectNumber)
+		if diff == best || worst == best {
+			scores[userID] = q.Points
+			continue
+		}
+		scores[userID] = int(math.Round(float64(q.Points) * (worst - diff) / (worst - best)))
+	}
+	return scores
+}
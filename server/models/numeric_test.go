@@ -0,0 +1,118 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func numericQuestion(correct float64, mode NumericMode, tolerance float64) *Question {
+	return &Question{
+		ID:            "n1",
+		Type:          QuestionTypeNumeric,
+		Points:        100,
+		CorrectNumber: &correct,
+		Numeric:       &NumericOptions{Mode: mode, Tolerance: tolerance},
+	}
+}
+
+func TestNumericIsCorrect(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      NumericMode
+		tolerance float64
+		correct   float64
+		answer    float64
+		want      bool
+	}{
+		{"exact match", NumericModeExact, 0, 42, 42, true},
+		{"exact miss", NumericModeExact, 0, 42, 42.5, false},
+		{"absolute inside", NumericModeAbsolute, 5, 100, 95, true},
+		{"absolute on the edge", NumericModeAbsolute, 5, 100, 105, true},
+		{"absolute outside", NumericModeAbsolute, 5, 100, 105.1, false},
+		{"percent inside", NumericModePercent, 10, 200, 181, true},
+		{"percent on the edge", NumericModePercent, 10, 200, 220, true},
+		{"percent outside", NumericModePercent, 10, 200, 221, false},
+		{"percent of a negative value", NumericModePercent, 10, -50, -54, true},
+		{"closest is never correct on submit", NumericModeClosest, 0, 42, 42, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := numericQuestion(tt.correct, tt.mode, tt.tolerance)
+			answer := tt.answer
+			if got := question.IsCorrect(AnswerValue{Number: &answer}); got != tt.want {
+				t.Errorf("IsCorrect(%v) = %v, want %v", tt.answer, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClosestScores(t *testing.T) {
+	tests := []struct {
+		name    string
+		numbers map[string]float64
+		want    map[string]int
+	}{
+		{
+			name:    "no answers",
+			numbers: map[string]float64{},
+			want:    map[string]int{},
+		},
+		{
+			name:    "single answer gets full points",
+			numbers: map[string]float64{"a": 10},
+			want:    map[string]int{"a": 100},
+		},
+		{
+			name:    "closest full, farthest zero, others scaled",
+			numbers: map[string]float64{"a": 100, "b": 150, "c": 200},
+			want:    map[string]int{"a": 100, "b": 50, "c": 0},
+		},
+		{
+			name:    "distance counts on both sides",
+			numbers: map[string]float64{"a": 90, "b": 125, "c": 60},
+			want:    map[string]int{"a": 100, "b": 50, "c": 0},
+		},
+		{
+			name:    "ties for closest all get full points",
+			numbers: map[string]float64{"a": 95, "b": 105, "c": 120},
+			want:    map[string]int{"a": 100, "b": 100, "c": 0},
+		},
+		{
+			name:    "everyone equally far gets full points",
+			numbers: map[string]float64{"a": 80, "b": 120},
+			want:    map[string]int{"a": 100, "b": 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := numericQuestion(100, NumericModeClosest, 0)
+			if got := question.ClosestScores(tt.numbers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClosestScores(%v) = %v, want %v", tt.numbers, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateNumeric(t *testing.T) {
+	tests := []struct {
+		name     string
+		question *Question
+		wantErr  bool
+	}{
+		{"exact", numericQuestion(1, NumericModeExact, 0), false},
+		{"closest", numericQuestion(1, NumericModeClosest, 0), false},
+		{"negative tolerance", numericQuestion(1, NumericModeAbsolute, -1), true},
+		{"unknown mode", numericQuestion(1, "nearest", 0), true},
+		{"missing correct number", &Question{ID: "n1", Type: QuestionTypeNumeric}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.question.validateNumeric(); (err != nil) != tt.wantErr {
+				t.Errorf("validateNumeric() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
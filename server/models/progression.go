@@ -0,0 +1,96 @@
+package models
+
+import "fmt"
+
+// validateProgression checks the settings against how the quiz moves through its questions.
+// Everyone sees the host's current question in a live quiz, so only self-paced quizzes can
+// give participants their own question order.
+func (s QuizSettings) validateProgression() error {
+	if s.ShuffleQuestions && !s.IsSelfPaced() {
+		return fmt.Errorf("shuffle_questions only applies to self-paced quizzes")
+	}
+	return nil
+}
+
+// CurrentQuestionIndex returns the index of the question the host is currently showing
+func (q *Quiz) CurrentQuestionIndex() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.CurrentQuestion
+}
+
+// GetCurrentQuestion returns the question currently shown, or nil when the quiz is not running
+func (q *Quiz) GetCurrentQuestion() *Question {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.currentQuestion()
+}
+
+func (q *Quiz) currentQuestion() *Question {
+	// Self-paced participants each have their own question cursor instead
+	if q.Settings.IsSelfPaced() {
+		return nil
+	}
+	if (q.Status != QuizStatusActive && q.Status != QuizStatusPaused) || q.CurrentQuestion < 0 || q.CurrentQuestion >= len(q.Questions) {
+		return nil
+	}
+	return &q.Questions[q.CurrentQuestion]
+}
+
+// IsCurrentQuestion reports whether questionID is the question currently shown
+func (q *Quiz) IsCurrentQuestion(questionID string) bool {
+	current := q.GetCurrentQuestion()
+	return current != nil && current.ID == questionID
+}
+
+// SetCurrentQuestion moves the pointer to index; it returns false if index is out of range
+func (q *Quiz) SetCurrentQuestion(index int) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if index < 0 || index >= len(q.Questions) {
+		return false
+	}
+	q.CurrentQuestion = index
+	return true
+}
+
+// AllAnswered reports whether every participant has answered a question
+func (q *Quiz) AllAnswered(questionID string) bool {
+	participants := q.GetParticipants()
+	if len(participants) == 0 {
+		return false
+	}
+	for _, user := range participants {
+		if !user.HasAnswered(questionID) {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrentQuestionUpdate builds the message announcing the current question.
+// When user is set, options follow that participant's shuffled order.
+func (q *Quiz) CurrentQuestionUpdate(user *User) *QuizUpdate {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	update := &QuizUpdate{
+		Type:          "current_question",
+		QuizID:        q.ID,
+		QuestionIndex: q.CurrentQuestion,
+		QuestionCount: len(q.Questions),
+		OpenedAt:      q.QuestionOpenedAt,
+		Deadline:      q.QuestionDeadline,
+	}
+	if question := q.currentQuestion(); question != nil {
+		update.TimeLimit = q.timeLimitFor(question)
+		update.Round = q.roundSummary(q.roundIndexOf(question.ID))
+		var optionOrder []int
+		if user != nil {
+			optionOrder = user.OptionOrder(question)
+		}
+		view := question.ParticipantView(optionOrder)
+		update.Question = &view
+	}
+	return update
+}
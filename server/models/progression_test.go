@@ -0,0 +1,104 @@
+package models
+
+import "testing"
+
+func TestValidateProgression(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		wantErr  bool
+	}{
+		{"defaults", QuizSettings{}, false},
+		{"live with shuffled options", QuizSettings{ShuffleOptions: true}, false},
+		{"live with shuffled questions", QuizSettings{ShuffleQuestions: true}, true},
+		{"explicitly live with shuffled questions", QuizSettings{Mode: QuizModeLive, ShuffleQuestions: true}, true},
+		{"self-paced with shuffled questions", QuizSettings{Mode: QuizModeSelfPaced, ShuffleQuestions: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCurrentQuestion(t *testing.T) {
+	questions := []Question{{ID: "q1"}, {ID: "q2"}, {ID: "q3"}}
+
+	tests := []struct {
+		name   string
+		status QuizStatus
+		mode   QuizMode
+		index  int
+		wantID string
+	}{
+		{"active", QuizStatusActive, QuizModeLive, 1, "q2"},
+		{"paused", QuizStatusPaused, QuizModeLive, 2, "q3"},
+		{"waiting", QuizStatusWaiting, QuizModeLive, 0, ""},
+		{"ended", QuizStatusEnded, QuizModeLive, 0, ""},
+		{"out of range", QuizStatusActive, QuizModeLive, 3, ""},
+		{"self-paced", QuizStatusActive, QuizModeSelfPaced, 0, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{Questions: questions, Status: tt.status, CurrentQuestion: tt.index, Settings: QuizSettings{Mode: tt.mode}}
+			got := quiz.GetCurrentQuestion()
+			if (got == nil) != (tt.wantID == "") || (got != nil && got.ID != tt.wantID) {
+				t.Errorf("GetCurrentQuestion() = %v, want %q", got, tt.wantID)
+			}
+			if tt.wantID != "" && !quiz.IsCurrentQuestion(tt.wantID) {
+				t.Errorf("IsCurrentQuestion(%q) = false", tt.wantID)
+			}
+		})
+	}
+}
+
+func TestSetCurrentQuestion(t *testing.T) {
+	quiz := &Quiz{Questions: []Question{{ID: "q1"}, {ID: "q2"}}, Status: QuizStatusActive}
+
+	tests := []struct {
+		index int
+		want  bool
+	}{
+		{1, true},
+		{0, true},
+		{-1, false},
+		{2, false},
+	}
+
+	for _, tt := range tests {
+		if got := quiz.SetCurrentQuestion(tt.index); got != tt.want {
+			t.Errorf("SetCurrentQuestion(%d) = %v, want %v", tt.index, got, tt.want)
+		}
+	}
+	if index := quiz.CurrentQuestionIndex(); index != 0 {
+		t.Errorf("current question = %d, want 0 after rejected moves", index)
+	}
+}
+
+func TestParticipantViewListsQuestionsShownSoFar(t *testing.T) {
+	quiz := &Quiz{
+		Questions: []Question{
+			{ID: "q1", Text: "One", Options: []string{"a", "b"}},
+			{ID: "q2", Text: "Two", Options: []string{"a", "b"}},
+			{ID: "q3", Text: "Three", Options: []string{"a", "b"}},
+		},
+		Participants:    map[string]*User{},
+		Status:          QuizStatusActive,
+		CurrentQuestion: 1,
+	}
+
+	view := quiz.ParticipantView(nil)
+	if len(view.Questions) != 2 || view.Questions[0].ID != "q1" || view.Questions[1].ID != "q2" {
+		t.Fatalf("questions = %+v, want q1 and q2", view.Questions)
+	}
+	if view.CurrentQuestion == nil || view.CurrentQuestion.ID != "q2" {
+		t.Errorf("current question = %+v, want q2", view.CurrentQuestion)
+	}
+	if view.QuestionIndex != 1 || view.QuestionCount != 3 {
+		t.Errorf("index %d of %d, want 1 of 3", view.QuestionIndex, view.QuestionCount)
+	}
+}
@@ -0,0 +1,222 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// QuestionType represents how a question is answered and checked
+type QuestionType string
+
+const (
+	QuestionTypeSingleChoice QuestionType = "single_choice"
+	QuestionTypeMultiSelect  QuestionType = "multi_select"
+	QuestionTypeTrueFalse    QuestionType = "true_false"
+	QuestionTypeOrdering     QuestionType = "ordering"
+	QuestionTypeFreeText     QuestionType = "free_text"
+	QuestionTypeNumeric      QuestionType = "numeric"
+)
+
+// AnswerValue is a submitted answer decoded according to the question type
+type AnswerValue struct {
+	Choice  int
+	Choices []int
+	Text    string
+	Number  *float64
+}
+
+// GetType returns the question type, defaulting to single choice
+func (q *Question) GetType() QuestionType {
+	if q.Type == "" {
+		return QuestionTypeSingleChoice
+	}
+	return q.Type
+}
+
+// UsesOptions reports whether participants answer by picking from the question's options
+func (q *Question) UsesOptions() bool {
+	return q.GetType() != QuestionTypeFreeText && q.GetType() != QuestionTypeNumeric
+}
+
+// GetTextMatch returns the free-text matching options, defaulting to full normalization
+func (q *Question) GetTextMatch() TextMatchOptions {
+	if q.TextMatch == nil {
+		return TextMatchOptions{}
+	}
+	return *q.TextMatch
+}
+
+// ApplyDefaults fills in type-specific defaults, such as the options of a true/false question
+func (q *Question) ApplyDefaults() {
+	if q.Type == "" {
+		q.Type = QuestionTypeSingleChoice
+	}
+	if q.Type == QuestionTypeTrueFalse && len(q.Options) == 0 {
+		q.Options = []string{"True", "False"}
+	}
+}
+
+// validateType checks the correctness data required by the question type
+func (q *Question) validateType() error {
+	switch q.GetType() {
+	case QuestionTypeSingleChoice:
+		if q.Correct < 0 || q.Correct >= len(q.Options) {
+			return fmt.Errorf("question %s: correct index %d out of range [0, %d)", q.ID, q.Correct, len(q.Options))
+		}
+	case QuestionTypeTrueFalse:
+		if len(q.Options) != 2 {
+			return fmt.Errorf("question %s: true/false questions must have exactly 2 options", q.ID)
+		}
+		if q.Correct != 0 && q.Correct != 1 {
+			return fmt.Errorf("question %s: correct must be 0 (true) or 1 (false)", q.ID)
+		}
+	case QuestionTypeMultiSelect:
+		if len(q.CorrectSet) == 0 {
+			return fmt.Errorf("question %s: correct_set must not be empty", q.ID)
+		}
+		if err := checkIndexes(q.CorrectSet, len(q.Options)); err != nil {
+			return fmt.Errorf("question %s: correct_set: %v", q.ID, err)
+		}
+	case QuestionTypeOrdering:
+		if len(q.CorrectOrder) != len(q.Options) {
+			return fmt.Errorf("question %s: correct_order must list all %d options", q.ID, len(q.Options))
+		}
+		if err := checkIndexes(q.CorrectOrder, len(q.Options)); err != nil {
+			return fmt.Errorf("question %s: correct_order: %v", q.ID, err)
+		}
+	case QuestionTypeFreeText:
+		if len(q.AcceptedAnswers) == 0 {
+			return fmt.Errorf("question %s: accepted_answers must not be empty", q.ID)
+		}
+		for i, accepted := range q.AcceptedAnswers {
+			if q.GetTextMatch().NormalizeText(accepted) == "" {
+				return fmt.Errorf("question %s: accepted answer %d is empty after normalization", q.ID, i)
+			}
+		}
+		if q.TextMatch != nil && q.TextMatch.MaxEditDistance < 0 {
+			return fmt.Errorf("question %s: max_edit_distance must not be negative", q.ID)
+		}
+	case QuestionTypeNumeric:
+		return q.validateNumeric()
+	default:
+		return fmt.Errorf("question %s: unknown question type %q", q.ID, q.Type)
+	}
+	return nil
+}
+
+// ParseAnswer decodes a raw answer payload into the shape expected by the question type:
+// an option index for single choice, a boolean (or 0/1) for true/false,
+// a list of option indexes for multi-select and ordering questions, a string for free text
+// and a number for numeric questions.
+func (q *Question) ParseAnswer(raw json.RawMessage) (AnswerValue, error) {
+	var value AnswerValue
+	if len(raw) == 0 {
+		return value, fmt.Errorf("answer is required")
+	}
+
+	switch q.GetType() {
+	case QuestionTypeSingleChoice:
+		if err := json.Unmarshal(raw, &value.Choice); err != nil {
+			return value, fmt.Errorf("answer must be an option index")
+		}
+		if value.Choice < 0 || value.Choice >= len(q.Options) {
+			return value, fmt.Errorf("answer index %d out of range", value.Choice)
+		}
+	case QuestionTypeTrueFalse:
+		var flag bool
+		if err := json.Unmarshal(raw, &flag); err == nil {
+			value.Choice = 1
+			if flag {
+				value.Choice = 0
+			}
+			return value, nil
+		}
+		if err := json.Unmarshal(raw, &value.Choice); err != nil || (value.Choice != 0 && value.Choice != 1) {
+			return value, fmt.Errorf("answer must be true or false")
+		}
+	case QuestionTypeMultiSelect:
+		if err := json.Unmarshal(raw, &value.Choices); err != nil {
+			return value, fmt.Errorf("answer must be a list of option indexes")
+		}
+		if err := checkIndexes(value.Choices, len(q.Options)); err != nil {
+			return value, err
+		}
+	case QuestionTypeOrdering:
+		if err := json.Unmarshal(raw, &value.Choices); err != nil {
+			return value, fmt.Errorf("answer must be an ordering of option indexes")
+		}
+		if len(value.Choices) != len(q.Options) {
+			return value, fmt.Errorf("answer must order all %d options", len(q.Options))
+		}
+		if err := checkIndexes(value.Choices, len(q.Options)); err != nil {
+			return value, err
+		}
+	case QuestionTypeFreeText:
+		if err := json.Unmarshal(raw, &value.Text); err != nil {
+			return value, fmt.Errorf("answer must be a string")
+		}
+		if strings.TrimSpace(value.Text) == "" {
+			return value, fmt.Errorf("answer must not be empty")
+		}
+	case QuestionTypeNumeric:
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return value, fmt.Errorf("answer must be a number")
+		}
+		value.Number = &number
+	default:
+		return value, fmt.Errorf("unknown question type %q", q.Type)
+	}
+	return value, nil
+}
+
+// IsCorrect checks a parsed answer against the question's correctness data.
+// Deferred (closest-wins) questions are never correct here; they are scored when the question closes.
+func (q *Question) IsCorrect(value AnswerValue) bool {
+	switch q.GetType() {
+	case QuestionTypeSingleChoice, QuestionTypeTrueFalse:
+		return value.Choice == q.Correct
+	case QuestionTypeMultiSelect:
+		if len(value.Choices) != len(q.CorrectSet) {
+			return false
+		}
+		expected := make(map[int]bool, len(q.CorrectSet))
+		for _, index := range q.CorrectSet {
+			expected[index] = true
+		}
+		for _, index := range value.Choices {
+			if !expected[index] {
+				return false
+			}
+		}
+		return true
+	case QuestionTypeOrdering:
+		for i := range q.CorrectOrder {
+			if value.Choices[i] != q.CorrectOrder[i] {
+				return false
+			}
+		}
+		return true
+	case QuestionTypeFreeText:
+		return q.GetTextMatch().Matches(value.Text, q.AcceptedAnswers)
+	case QuestionTypeNumeric:
+		return !q.IsDeferred() && q.numberMatches(*value.Number)
+	}
+	return false
+}
+
+// checkIndexes verifies that indexes are unique and within [0, n)
+func checkIndexes(indexes []int, n int) error {
+	seen := make(map[int]bool, len(indexes))
+	for _, index := range indexes {
+		if index < 0 || index >= n {
+			return fmt.Errorf("option index %d out of range [0, %d)", index, n)
+		}
+		if seen[index] {
+			return fmt.Errorf("duplicate option index %d", index)
+		}
+		seen[index] = true
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseAnswer(t *testing.T) {
+	choice := Question{ID: "c1", Options: []string{"a", "b", "c"}, Correct: 1}
+	trueFalse := Question{ID: "t1", Type: QuestionTypeTrueFalse, Options: []string{"True", "False"}}
+	multi := Question{ID: "m1", Type: QuestionTypeMultiSelect, Options: []string{"a", "b", "c"}, CorrectSet: []int{0, 2}}
+	ordering := Question{ID: "o1", Type: QuestionTypeOrdering, Options: []string{"a", "b", "c"}, CorrectOrder: []int{2, 0, 1}}
+
+	tests := []struct {
+		name     string
+		question Question
+		raw      string
+		want     AnswerValue
+		wantErr  bool
+	}{
+		{"single choice", choice, `2`, AnswerValue{Choice: 2}, false},
+		{"single choice out of range", choice, `3`, AnswerValue{}, true},
+		{"single choice negative", choice, `-1`, AnswerValue{}, true},
+		{"single choice not a number", choice, `"b"`, AnswerValue{}, true},
+		{"empty answer", choice, ``, AnswerValue{}, true},
+		{"true", trueFalse, `true`, AnswerValue{Choice: 0}, false},
+		{"false", trueFalse, `false`, AnswerValue{Choice: 1}, false},
+		{"true/false as index", trueFalse, `1`, AnswerValue{Choice: 1}, false},
+		{"true/false bad index", trueFalse, `2`, AnswerValue{}, true},
+		{"multi-select", multi, `[2, 0]`, AnswerValue{Choices: []int{2, 0}}, false},
+		{"multi-select empty", multi, `[]`, AnswerValue{Choices: []int{}}, false},
+		{"multi-select duplicate", multi, `[0, 0]`, AnswerValue{}, true},
+		{"multi-select out of range", multi, `[3]`, AnswerValue{}, true},
+		{"ordering", ordering, `[2, 0, 1]`, AnswerValue{Choices: []int{2, 0, 1}}, false},
+		{"ordering missing an option", ordering, `[2, 0]`, AnswerValue{}, true},
+		{"ordering repeats an option", ordering, `[2, 2, 1]`, AnswerValue{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.question.ParseAnswer(json.RawMessage(tt.raw))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAnswer(%s) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAnswer(%s) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsCorrect(t *testing.T) {
+	choice := Question{ID: "c1", Options: []string{"a", "b", "c"}, Correct: 1}
+	trueFalse := Question{ID: "t1", Type: QuestionTypeTrueFalse, Options: []string{"True", "False"}, Correct: 1}
+	multi := Question{ID: "m1", Type: QuestionTypeMultiSelect, Options: []string{"a", "b", "c"}, CorrectSet: []int{0, 2}}
+	ordering := Question{ID: "o1", Type: QuestionTypeOrdering, Options: []string{"a", "b", "c"}, CorrectOrder: []int{2, 0, 1}}
+
+	tests := []struct {
+		name     string
+		question Question
+		value    AnswerValue
+		want     bool
+	}{
+		{"single choice right", choice, AnswerValue{Choice: 1}, true},
+		{"single choice wrong", choice, AnswerValue{Choice: 0}, false},
+		{"false is right", trueFalse, AnswerValue{Choice: 1}, true},
+		{"true is wrong", trueFalse, AnswerValue{Choice: 0}, false},
+		{"multi-select exact set", multi, AnswerValue{Choices: []int{0, 2}}, true},
+		{"multi-select in any order", multi, AnswerValue{Choices: []int{2, 0}}, true},
+		{"multi-select missing one", multi, AnswerValue{Choices: []int{0}}, false},
+		{"multi-select one too many", multi, AnswerValue{Choices: []int{0, 1, 2}}, false},
+		{"multi-select wrong one", multi, AnswerValue{Choices: []int{0, 1}}, false},
+		{"ordering right", ordering, AnswerValue{Choices: []int{2, 0, 1}}, true},
+		{"ordering wrong", ordering, AnswerValue{Choices: []int{0, 2, 1}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.IsCorrect(tt.value); got != tt.want {
+				t.Errorf("IsCorrect(%+v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateQuestionType(t *testing.T) {
+	tests := []struct {
+		name     string
+		question Question
+		wantErr  bool
+	}{
+		{"single choice", Question{Options: []string{"a", "b"}, Correct: 1}, false},
+		{"single choice out of range", Question{Options: []string{"a", "b"}, Correct: 2}, true},
+		{"true/false", Question{Type: QuestionTypeTrueFalse, Options: []string{"True", "False"}, Correct: 0}, false},
+		{"true/false with three options", Question{Type: QuestionTypeTrueFalse, Options: []string{"a", "b", "c"}}, true},
+		{"true/false correct out of range", Question{Type: QuestionTypeTrueFalse, Options: []string{"True", "False"}, Correct: 2}, true},
+		{"multi-select", Question{Type: QuestionTypeMultiSelect, Options: []string{"a", "b"}, CorrectSet: []int{0, 1}}, false},
+		{"multi-select without answers", Question{Type: QuestionTypeMultiSelect, Options: []string{"a", "b"}}, true},
+		{"multi-select duplicate answer", Question{Type: QuestionTypeMultiSelect, Options: []string{"a", "b"}, CorrectSet: []int{1, 1}}, true},
+		{"ordering", Question{Type: QuestionTypeOrdering, Options: []string{"a", "b"}, CorrectOrder: []int{1, 0}}, false},
+		{"ordering incomplete", Question{Type: QuestionTypeOrdering, Options: []string{"a", "b"}, CorrectOrder: []int{1}}, true},
+		{"ordering out of range", Question{Type: QuestionTypeOrdering, Options: []string{"a", "b"}, CorrectOrder: []int{1, 2}}, true},
+		{"unknown type", Question{Type: "essay", Options: []string{"a"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.question.validateType(); (err != nil) != tt.wantErr {
+				t.Errorf("validateType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyDefaults(t *testing.T) {
+	question := Question{Type: QuestionTypeTrueFalse}
+	question.ApplyDefaults()
+	if !reflect.DeepEqual(question.Options, []string{"True", "False"}) {
+		t.Errorf("true/false options = %v, want [True False]", question.Options)
+	}
+
+	question = Question{}
+	question.ApplyDefaults()
+	if question.Type != QuestionTypeSingleChoice {
+		t.Errorf("type = %q, want single choice", question.Type)
+	}
+}
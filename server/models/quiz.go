@@ -2,21 +2,33 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 )
 
 // Quiz represents a quiz session
 type Quiz struct {
-	ID          string            `json:"id"`
-	Title       string            `json:"title"`
-	Questions   []Question        `json:"questions"`
-	Participants map[string]*User `json:"participants"`
-	Status      QuizStatus        `json:"status"`
-	CreatedAt   time.Time         `json:"created_at"`
-	StartedAt   *time.Time        `json:"started_at,omitempty"`
-	EndedAt     *time.Time        `json:"ended_at,omitempty"`
-	mu          sync.RWMutex      `json:"-"`
+	ID               string           `json:"id"`
+	Title            string           `json:"title"`
+	Questions        []Question       `json:"questions"`
+	Rounds           []Round          `json:"rounds,omitempty"`
+	Participants     map[string]*User `json:"participants"`
+	Status           QuizStatus       `json:"status"`
+	Settings         QuizSettings     `json:"settings"`
+	CreatedAt        time.Time        `json:"created_at"`
+	StartedAt        *time.Time       `json:"started_at,omitempty"`
+	EndedAt          *time.Time       `json:"ended_at,omitempty"`
+	ScheduledStart   *time.Time       `json:"scheduled_start,omitempty"`
+	ClosedQuestions  map[string]bool  `json:"closed_questions,omitempty"`
+	CurrentQuestion  int              `json:"current_question"`
+	QuestionOpenedAt *time.Time       `json:"question_opened_at,omitempty"`
+	QuestionDeadline *time.Time       `json:"question_deadline,omitempty"`
+	PausedAt         *time.Time       `json:"paused_at,omitempty"`
+	Intermission     bool             `json:"intermission,omitempty"` // Between rounds, waiting for the host
+	Locked           bool             `json:"locked"`
+	HostToken        string           `json:"host_token,omitempty"`
+	mu               sync.RWMutex     `json:"-"`
 }
 
 // QuizStatus represents the current status of a quiz
@@ -25,44 +37,108 @@ type QuizStatus string
 const (
 	QuizStatusWaiting QuizStatus = "waiting"
 	QuizStatusActive  QuizStatus = "active"
+	QuizStatusPaused  QuizStatus = "paused"
 	QuizStatusEnded   QuizStatus = "ended"
 )
 
+// QuizSettings holds per-quiz options chosen by the host
+type QuizSettings struct {
+	ShuffleQuestions bool `json:"shuffle_questions"`
+	ShuffleOptions   bool `json:"shuffle_options"`
+	TimeLimit        int  `json:"time_limit,omitempty"`   // Default seconds per question, 0 = untimed
+	AutoAdvance      bool `json:"auto_advance,omitempty"` // Close and advance when time is up or everyone answered
+
+	MaxParticipants int            `json:"max_participants,omitempty"` // 0 = unlimited
+	LateJoin        LateJoinPolicy `json:"late_join,omitempty"`        // Defaults to allow
+
+	Mode             QuizMode   `json:"mode,omitempty"`               // Defaults to live
+	AttemptTimeLimit int        `json:"attempt_time_limit,omitempty"` // Self-paced seconds per participant, 0 = unlimited
+	OpensAt          *time.Time `json:"opens_at,omitempty"`           // Self-paced attempts may start from
+	ClosesAt         *time.Time `json:"closes_at,omitempty"`          // Self-paced attempts must end by
+
+	Scoring          ScoringMode `json:"scoring,omitempty"`            // Defaults to fixed
+	MinPointsPercent *int        `json:"min_points_percent,omitempty"` // Time decay floor, defaults to 50
+	DecayTime        int         `json:"decay_time,omitempty"`         // Time decay seconds for untimed questions, defaults to 30
+
+	PartialCredit       bool    `json:"partial_credit,omitempty"`        // Partly right multi-select and ordering answers earn a share
+	WrongPenaltyPercent int     `json:"wrong_penalty_percent,omitempty"` // Negative marking, share of the points deducted
+	NoSkipPenalty       bool    `json:"no_skip_penalty,omitempty"`       // Unanswered questions cost nothing under negative marking
+	StreakBonus         float64 `json:"streak_bonus,omitempty"`          // Extra share of points per consecutive correct answer
+	MaxStreakBonus      float64 `json:"max_streak_bonus,omitempty"`      // Cap on the streak bonus share, defaults to 1
+}
+
 // Question represents a quiz question
 type Question struct {
-	ID       string   `json:"id"`
-	Text     string   `json:"text"`
-	Options  []string `json:"options"`
-	Correct  int      `json:"correct"`
-	Points   int      `json:"points"`
-	Category string   `json:"category"`
+	ID           string       `json:"id"`
+	Type         QuestionType `json:"type,omitempty"`
+	Text         string       `json:"text"`
+	Options      []string     `json:"options"`
+	Correct      int          `json:"correct"`
+	CorrectSet   []int        `json:"correct_set,omitempty"`
+	CorrectOrder []int        `json:"correct_order,omitempty"`
+	Points       int          `json:"points"`
+	Category     string       `json:"category"`
+	Tags         []string     `json:"tags,omitempty"`
+	TimeLimit    int          `json:"time_limit,omitempty"` // Seconds, overrides the quiz default
+
+	// Free-text questions
+	AcceptedAnswers []string          `json:"accepted_answers,omitempty"`
+	TextMatch       *TextMatchOptions `json:"text_match,omitempty"`
+
+	// Numeric questions
+	CorrectNumber *float64        `json:"correct_number,omitempty"`
+	Numeric       *NumericOptions `json:"numeric,omitempty"`
 }
 
 // User represents a participant in a quiz
 type User struct {
-	ID       string    `json:"id"`
-	Name     string    `json:"name"`
-	Score    int       `json:"score"`
-	Answers  []Answer  `json:"answers"`
-	JoinedAt time.Time `json:"joined_at"`
-	mu       sync.RWMutex `json:"-"`
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	Score         int              `json:"score"`
+	Answers       []Answer         `json:"answers"`
+	JoinedAt      time.Time        `json:"joined_at"`
+	QuestionOrder []string         `json:"question_order,omitempty"`
+	OptionOrders  map[string][]int `json:"option_orders,omitempty"`
+	LateQuestions []string         `json:"late_questions,omitempty"` // Closed before the user joined, answerable without points
+
+	// Self-paced attempt
+	AttemptStartedAt  *time.Time `json:"attempt_started_at,omitempty"`
+	AttemptDeadline   *time.Time `json:"attempt_deadline,omitempty"`
+	AttemptFinishedAt *time.Time `json:"attempt_finished_at,omitempty"`
+	Cursor            int        `json:"cursor"`
+	CursorAt          *time.Time `json:"cursor_at,omitempty"` // When the user reached the question at the cursor
+
+	mu sync.RWMutex `json:"-"`
 }
 
 // Answer represents a user's answer to a question
 type Answer struct {
-	QuestionID string    `json:"question_id"`
-	Answer     int       `json:"answer"`
-	Correct    bool      `json:"correct"`
-	Points     int       `json:"points"`
-	AnsweredAt time.Time `json:"answered_at"`
+	QuestionID string          `json:"question_id"`
+	Answer     int             `json:"answer"`
+	Choices    []int           `json:"choices,omitempty"`
+	Text       string          `json:"text,omitempty"`
+	Number     *float64        `json:"number,omitempty"`
+	Pending    bool            `json:"pending,omitempty"`
+	Skipped    bool            `json:"skipped,omitempty"` // Recorded when an unanswered question closed
+	Correct    bool            `json:"correct"`
+	Points     int             `json:"points"`
+	Breakdown  *ScoreBreakdown `json:"breakdown,omitempty"`
+	AnsweredAt time.Time       `json:"answered_at"`
+	ResponseMs *int64          `json:"response_ms,omitempty"` // Since the question was shown to the user
 }
 
 // LeaderboardEntry represents an entry in the leaderboard
 type LeaderboardEntry struct {
-	UserID   string `json:"user_id"`
-	Name     string `json:"name"`
-	Score    int    `json:"score"`
-	Position int    `json:"position"`
+	UserID        string `json:"user_id"`
+	Name          string `json:"name"`
+	Score         int    `json:"score"`
+	Position      int    `json:"position"`                  // Shared by equal scores: 1, 1, 3
+	AvgResponseMs *int64 `json:"avg_response_ms,omitempty"` // Over the user's timed answers
+	CorrectTimeMs int64  `json:"correct_time_ms"`           // Total response time of correct answers
+
+	// Tie-breakers after correct time
+	lastAnswerAt time.Time
+	joinedAt     time.Time
 }
 
 // WebSocketMessage represents a message sent via WebSocket
@@ -71,6 +147,48 @@ type WebSocketMessage struct {
 	Payload interface{} `json:"payload"`
 }
 
+// CreateQuizRequest represents a request to create a quiz
+type CreateQuizRequest struct {
+	Title              string       `json:"title"`
+	Questions          []Question   `json:"questions"`
+	Rounds             []Round      `json:"rounds,omitempty"`
+	UseSampleQuestions bool         `json:"use_sample_questions"`
+	FromBank           []BankDraw   `json:"from_bank,omitempty"`
+	Settings           QuizSettings `json:"settings"`
+	ScheduledStart     *time.Time   `json:"scheduled_start,omitempty"`
+}
+
+// CreateTemplateRequest represents a request to save a quiz template
+type CreateTemplateRequest struct {
+	Title     string       `json:"title"`
+	Questions []Question   `json:"questions"`
+	Rounds    []Round      `json:"rounds,omitempty"`
+	Settings  QuizSettings `json:"settings"`
+}
+
+// CopyQuizRequest optionally overrides the title when cloning a quiz,
+// saving it as a template or instantiating a template
+type CopyQuizRequest struct {
+	Title string `json:"title"`
+}
+
+// BankDraw describes how many random questions to draw from a question bank category
+type BankDraw struct {
+	Category string   `json:"category"`
+	Count    int      `json:"count"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// QuizTemplate is a saved quiz definition that can be instantiated into new quizzes
+type QuizTemplate struct {
+	ID        string       `json:"id"`
+	Title     string       `json:"title"`
+	Questions []Question   `json:"questions"`
+	Rounds    []Round      `json:"rounds,omitempty"`
+	Settings  QuizSettings `json:"settings"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
 // JoinQuizRequest represents a request to join a quiz
 type JoinQuizRequest struct {
 	QuizID string `json:"quiz_id"`
@@ -78,19 +196,26 @@ type JoinQuizRequest struct {
 }
 
 // SubmitAnswerRequest represents a request to submit an answer
+// Answer holds an option index, a boolean, a list of indexes or a string depending on the question type
 type SubmitAnswerRequest struct {
-	QuizID     string `json:"quiz_id"`
-	QuestionID string `json:"question_id"`
-	Answer     int    `json:"answer"`
+	QuizID     string          `json:"quiz_id"`
+	QuestionID string          `json:"question_id"`
+	Answer     json.RawMessage `json:"answer"`
 }
 
 // QuizUpdate represents an update to the quiz state
 type QuizUpdate struct {
-	Type      string              `json:"type"`
-	QuizID    string              `json:"quiz_id"`
-	Leaderboard []LeaderboardEntry `json:"leaderboard,omitempty"`
-	Question   *Question          `json:"question,omitempty"`
-	UserScore  *UserScore         `json:"user_score,omitempty"`
+	Type          string               `json:"type"`
+	QuizID        string               `json:"quiz_id"`
+	Leaderboard   []LeaderboardEntry   `json:"leaderboard,omitempty"`
+	Question      *ParticipantQuestion `json:"question,omitempty"`
+	QuestionIndex int                  `json:"question_index"`
+	QuestionCount int                  `json:"question_count"`
+	TimeLimit     int                  `json:"time_limit,omitempty"`
+	OpenedAt      *time.Time           `json:"opened_at,omitempty"`
+	Deadline      *time.Time           `json:"deadline,omitempty"`
+	Round         *RoundSummary        `json:"round,omitempty"`
+	UserScore     *UserScore           `json:"user_score,omitempty"`
 }
 
 // UserScore represents a user's score update
@@ -104,8 +229,21 @@ type UserScore struct {
 const (
 	QuizKeyPrefix        = "quiz:"
 	UserKeyPrefix        = "user:"
-	LeaderboardKeyPrefix = "leaderboard:"
+	LeaderboardKeyPrefix = "leaderboard:" // Sorted set of user scores
 	ActiveQuizzesKey     = "active_quizzes"
+
+	ArchiveKeyPrefix   = "archive:quiz:"
+	ArchivedQuizzesKey = "archived_quizzes" // Sorted set scored by end time
+
+	QuestionKeyPrefix         = "question:"
+	QuestionBankKey           = "question_bank"
+	QuestionCategoryKeyPrefix = "question_category:"
+	QuestionTagKeyPrefix      = "question_tag:"
+
+	TemplateKeyPrefix = "template:"
+	TemplatesKey      = "quiz_templates"
+
+	ScheduleLockKeyPrefix = "schedule_lock:"
 )
 
 // Methods for Quiz
@@ -131,30 +269,154 @@ func (q *Quiz) GetLeaderboard() []LeaderboardEntry {
 	q.mu.RLock()
 	defer q.mu.RUnlock()
 	
+	// Self-paced quizzes rank completed attempts only
+	now := time.Now()
 	entries := make([]LeaderboardEntry, 0, len(q.Participants))
 	for _, user := range q.Participants {
-		entries = append(entries, LeaderboardEntry{
-			UserID: user.ID,
-			Name:   user.Name,
-			Score:  user.Score,
-		})
+		if q.Settings.IsSelfPaced() && !user.AttemptComplete(now) {
+			continue
+		}
+		entries = append(entries, user.leaderboardEntry())
 	}
 	
-	// Sort by score (descending)
-	for i := 0; i < len(entries)-1; i++ {
-		for j := i + 1; j < len(entries); j++ {
-			if entries[i].Score < entries[j].Score {
-				entries[i], entries[j] = entries[j], entries[i]
-			}
+	return rankEntries(entries)
+}
+
+// GetStatus returns the quiz status
+func (q *Quiz) GetStatus() QuizStatus {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.Status
+}
+
+// UpdateStatus replaces the status with update's result while holding the quiz lock
+func (q *Quiz) UpdateStatus(update func(QuizStatus) QuizStatus) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.Status = update(q.Status)
+}
+
+// ResetProgress takes the quiz back to its pre-start state, keeping participants but
+// clearing their answers and scores
+func (q *Quiz) ResetProgress() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.StartedAt, q.EndedAt, q.ScheduledStart, q.PausedAt = nil, nil, nil, nil
+	q.ClosedQuestions = nil
+	q.CurrentQuestion = 0
+	q.QuestionOpenedAt, q.QuestionDeadline = nil, nil
+	q.Intermission = false
+	for _, user := range q.Participants {
+		user.ResetAnswers()
+	}
+}
+
+// SetQuestions replaces the quiz questions, keeping rounds in step with them
+func (q *Quiz) SetQuestions(questions []Question) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.Rounds, q.Questions = SyncRounds(q.Rounds, questions)
+}
+
+// CloseQuestion marks a question as closed; it returns false if it was already closed
+func (q *Quiz) CloseQuestion(questionID string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.ClosedQuestions == nil {
+		q.ClosedQuestions = make(map[string]bool)
+	}
+	if q.ClosedQuestions[questionID] {
+		return false
+	}
+	q.ClosedQuestions[questionID] = true
+	return true
+}
+
+func (q *Quiz) IsQuestionClosed(questionID string) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.ClosedQuestions[questionID]
+}
+
+// Methods for Question
+func (q *Question) Validate() error {
+	if q.Text == "" {
+		return fmt.Errorf("question %s: text is required", q.ID)
+	}
+	if len(q.Options) == 0 && q.UsesOptions() {
+		return fmt.Errorf("question %s: options must not be empty", q.ID)
+	}
+	for i, option := range q.Options {
+		if option == "" {
+			return fmt.Errorf("question %s: option %d is empty", q.ID, i)
 		}
 	}
-	
-	// Add positions
-	for i := range entries {
-		entries[i].Position = i + 1
+	if err := q.validateType(); err != nil {
+		return err
 	}
-	
-	return entries
+	if q.Points <= 0 {
+		return fmt.Errorf("question %s: points must be positive", q.ID)
+	}
+	if q.TimeLimit < 0 {
+		return fmt.Errorf("question %s: time limit must not be negative", q.ID)
+	}
+	return nil
+}
+
+// Clone returns a deep copy of the question
+func (q Question) Clone() Question {
+	q.Options = append([]string(nil), q.Options...)
+	q.CorrectSet = append([]int(nil), q.CorrectSet...)
+	q.CorrectOrder = append([]int(nil), q.CorrectOrder...)
+	q.Tags = append([]string(nil), q.Tags...)
+	q.AcceptedAnswers = append([]string(nil), q.AcceptedAnswers...)
+	if q.TextMatch != nil {
+		textMatch := *q.TextMatch
+		q.TextMatch = &textMatch
+	}
+	if q.CorrectNumber != nil {
+		number := *q.CorrectNumber
+		q.CorrectNumber = &number
+	}
+	if q.Numeric != nil {
+		numeric := *q.Numeric
+		q.Numeric = &numeric
+	}
+	return q
+}
+
+// CloneQuestions returns a deep copy of a question list
+func CloneQuestions(questions []Question) []Question {
+	cloned := make([]Question, len(questions))
+	for i := range questions {
+		cloned[i] = questions[i].Clone()
+	}
+	return cloned
+}
+
+// ValidateQuestions validates a question list and checks that question IDs are unique
+func ValidateQuestions(questions []Question) error {
+	if len(questions) == 0 {
+		return fmt.Errorf("at least one question is required")
+	}
+
+	seen := make(map[string]bool, len(questions))
+	for i := range questions {
+		if questions[i].ID == "" {
+			return fmt.Errorf("question %d: id is required", i)
+		}
+		if seen[questions[i].ID] {
+			return fmt.Errorf("duplicate question id: %s", questions[i].ID)
+		}
+		seen[questions[i].ID] = true
+
+		questions[i].ApplyDefaults()
+		if err := questions[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Methods for User
@@ -162,9 +424,18 @@ func (u *User) AddAnswer(answer Answer) {
 	u.mu.Lock()
 	defer u.mu.Unlock()
 	u.Answers = append(u.Answers, answer)
-	if answer.Correct {
-		u.Score += answer.Points
-	}
+	u.Score += answer.Points // Partial credit earns points without being correct, penalties subtract
+}
+
+// ResetAnswers clears the user's answers and score
+func (u *User) ResetAnswers() {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.Answers = []Answer{}
+	u.Score = 0
+	u.LateQuestions = nil
+	u.AttemptStartedAt, u.AttemptDeadline, u.AttemptFinishedAt, u.CursorAt = nil, nil, nil, nil
+	u.Cursor = 0
 }
 
 func (u *User) GetScore() int {
@@ -184,6 +455,34 @@ func (u *User) HasAnswered(questionID string) bool {
 	return false
 }
 
+// GetAnswer returns the user's answer to a question, if any
+func (u *User) GetAnswer(questionID string) (Answer, bool) {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+	for _, answer := range u.Answers {
+		if answer.QuestionID == questionID {
+			return answer, true
+		}
+	}
+	return Answer{}, false
+}
+
+// ResolveAnswer scores a pending answer once its question has closed
+func (u *User) ResolveAnswer(questionID string, correct bool, points int) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for i := range u.Answers {
+		if u.Answers[i].QuestionID == questionID && u.Answers[i].Pending {
+			u.Answers[i].Pending = false
+			u.Answers[i].Correct = correct
+			u.Answers[i].Points = points
+			u.Score += points
+			return true
+		}
+	}
+	return false
+}
+
 // Redis serialization methods
 func (q *Quiz) ToJSON() ([]byte, error) {
 	return json.Marshal(q)
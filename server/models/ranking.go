@@ -0,0 +1,84 @@
+package models
+
+import "sort"
+
+// leaderboardEntry summarizes the user for ranking
+func (u *User) leaderboardEntry() LeaderboardEntry {
+	average := u.AverageResponseMs()
+
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	entry := LeaderboardEntry{
+		UserID:        u.ID,
+		Name:          u.Name,
+		Score:         u.Score,
+		AvgResponseMs: average,
+		joinedAt:      u.JoinedAt,
+	}
+	for _, answer := range u.Answers {
+		if answer.Skipped {
+			continue
+		}
+		if answer.Correct && answer.ResponseMs != nil {
+			entry.CorrectTimeMs += *answer.ResponseMs
+		}
+		if answer.AnsweredAt.After(entry.lastAnswerAt) {
+			entry.lastAnswerAt = answer.AnsweredAt
+		}
+	}
+	return entry
+}
+
+// rankEntries orders leaderboard entries by score, then by the tie-breakers: least total
+// correct-answer time, earliest final answer, earliest join. Equal scores share a position
+// (competition ranking: 1, 1, 3), the tie-breakers only decide the order they are listed in.
+func rankEntries(entries []LeaderboardEntry) []LeaderboardEntry {
+	return RankBlock(entries, 0)
+}
+
+// RankBlock ranks a contiguous block of leaderboard entries like rankEntries, numbering positions
+// after the ahead entries with higher scores that precede the block
+func RankBlock(entries []LeaderboardEntry, ahead int) []LeaderboardEntry {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.CorrectTimeMs != b.CorrectTimeMs {
+			return a.CorrectTimeMs < b.CorrectTimeMs
+		}
+		if !a.lastAnswerAt.Equal(b.lastAnswerAt) {
+			// Participants who never answered go last
+			if a.lastAnswerAt.IsZero() || b.lastAnswerAt.IsZero() {
+				return b.lastAnswerAt.IsZero()
+			}
+			return a.lastAnswerAt.Before(b.lastAnswerAt)
+		}
+		if !a.joinedAt.Equal(b.joinedAt) {
+			return a.joinedAt.Before(b.joinedAt)
+		}
+		return a.UserID < b.UserID
+	})
+
+	for i := range entries {
+		if i > 0 && entries[i].Score == entries[i-1].Score {
+			entries[i].Position = entries[i-1].Position
+		} else {
+			entries[i].Position = ahead + i + 1
+		}
+	}
+	return entries
+}
+
+// LeaderboardEntryFor summarizes a participant, with their tie-breakers, for a leaderboard
+// ranked elsewhere. It returns false if the user is not a participant.
+func (q *Quiz) LeaderboardEntryFor(userID string) (LeaderboardEntry, bool) {
+	q.mu.RLock()
+	user, exists := q.Participants[userID]
+	q.mu.RUnlock()
+	if !exists {
+		return LeaderboardEntry{}, false
+	}
+	return user.leaderboardEntry(), true
+}
@@ -0,0 +1,190 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRankEntries(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name          string
+		entries       []LeaderboardEntry
+		wantOrder     []string
+		wantPositions []int
+	}{
+		{
+			name:          "empty",
+			entries:       []LeaderboardEntry{},
+			wantOrder:     []string{},
+			wantPositions: []int{},
+		},
+		{
+			name: "distinct scores",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 10},
+				{UserID: "b", Score: 30},
+				{UserID: "c", Score: 20},
+			},
+			wantOrder:     []string{"b", "c", "a"},
+			wantPositions: []int{1, 2, 3},
+		},
+		{
+			name: "equal scores share a position",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 50},
+				{UserID: "b", Score: 50},
+				{UserID: "c", Score: 20},
+			},
+			wantOrder:     []string{"a", "b", "c"},
+			wantPositions: []int{1, 1, 3},
+		},
+		{
+			name: "tie in the middle",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 90},
+				{UserID: "b", Score: 40},
+				{UserID: "c", Score: 40},
+				{UserID: "d", Score: 40},
+				{UserID: "e", Score: 10},
+			},
+			wantOrder:     []string{"a", "b", "c", "d", "e"},
+			wantPositions: []int{1, 2, 2, 2, 5},
+		},
+		{
+			name: "faster correct answers listed first",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 50, CorrectTimeMs: 9000},
+				{UserID: "b", Score: 50, CorrectTimeMs: 3000},
+			},
+			wantOrder:     []string{"b", "a"},
+			wantPositions: []int{1, 1},
+		},
+		{
+			name: "earlier final answer listed first",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 50, lastAnswerAt: at(20)},
+				{UserID: "b", Score: 50, lastAnswerAt: at(10)},
+			},
+			wantOrder:     []string{"b", "a"},
+			wantPositions: []int{1, 1},
+		},
+		{
+			name: "never answered listed last",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 0, joinedAt: at(0)},
+				{UserID: "b", Score: 0, lastAnswerAt: at(30), joinedAt: at(5)},
+			},
+			wantOrder:     []string{"b", "a"},
+			wantPositions: []int{1, 1},
+		},
+		{
+			name: "earlier join listed first",
+			entries: []LeaderboardEntry{
+				{UserID: "a", Score: 50, lastAnswerAt: at(10), joinedAt: at(2)},
+				{UserID: "b", Score: 50, lastAnswerAt: at(10), joinedAt: at(1)},
+			},
+			wantOrder:     []string{"b", "a"},
+			wantPositions: []int{1, 1},
+		},
+		{
+			name: "user id as the final tie-breaker",
+			entries: []LeaderboardEntry{
+				{UserID: "b", Score: 50},
+				{UserID: "a", Score: 50},
+			},
+			wantOrder:     []string{"a", "b"},
+			wantPositions: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := rankEntries(tt.entries)
+			order, positions := make([]string, 0), make([]int, 0)
+			for _, entry := range ranked {
+				order = append(order, entry.UserID)
+				positions = append(positions, entry.Position)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("positions = %v, want %v", positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestRankBlock(t *testing.T) {
+	tests := []struct {
+		name          string
+		ahead         int
+		entries       []LeaderboardEntry
+		wantPositions []int
+	}{
+		{
+			name:          "block after higher scores",
+			ahead:         3,
+			entries:       []LeaderboardEntry{{UserID: "a", Score: 20}, {UserID: "b", Score: 40}},
+			wantPositions: []int{4, 5},
+		},
+		{
+			name:          "tie at the start of the block",
+			ahead:         2,
+			entries:       []LeaderboardEntry{{UserID: "a", Score: 40}, {UserID: "b", Score: 40}, {UserID: "c", Score: 10}},
+			wantPositions: []int{3, 3, 5},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			positions := make([]int, 0)
+			for _, entry := range RankBlock(tt.entries, tt.ahead) {
+				positions = append(positions, entry.Position)
+			}
+			if !reflect.DeepEqual(positions, tt.wantPositions) {
+				t.Errorf("positions = %v, want %v", positions, tt.wantPositions)
+			}
+		})
+	}
+}
+
+func TestLeaderboardEntry(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	ms := func(value int64) *int64 { return &value }
+
+	user := &User{
+		ID:       "u1",
+		Name:     "Alice",
+		Score:    25,
+		JoinedAt: base,
+		Answers: []Answer{
+			{QuestionID: "q1", Correct: true, ResponseMs: ms(2000), AnsweredAt: base.Add(10 * time.Second)},
+			{QuestionID: "q2", Correct: false, ResponseMs: ms(5000), AnsweredAt: base.Add(30 * time.Second)},
+			{QuestionID: "q3", Correct: true, ResponseMs: ms(1000), AnsweredAt: base.Add(20 * time.Second)},
+			{QuestionID: "q4", Skipped: true, AnsweredAt: base.Add(60 * time.Second)},
+		},
+	}
+
+	entry := user.leaderboardEntry()
+	if entry.UserID != "u1" || entry.Name != "Alice" || entry.Score != 25 {
+		t.Errorf("entry = %+v, want u1 Alice with 25 points", entry)
+	}
+	if entry.CorrectTimeMs != 3000 {
+		t.Errorf("correct time = %d, want 3000", entry.CorrectTimeMs)
+	}
+	if entry.AvgResponseMs == nil || *entry.AvgResponseMs != 2666 {
+		t.Errorf("average response = %v, want 2666", entry.AvgResponseMs)
+	}
+	// Skipped questions are not answers
+	if want := base.Add(30 * time.Second); !entry.lastAnswerAt.Equal(want) {
+		t.Errorf("last answer at = %v, want %v", entry.lastAnswerAt, want)
+	}
+	if !entry.joinedAt.Equal(base) {
+		t.Errorf("joined at = %v, want %v", entry.joinedAt, base)
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResultRow is one participant's result for one question, as exported after a quiz
+type ResultRow struct {
+	Position     *int       `json:"position"` // Nil for participants not on the leaderboard
+	UserID       string     `json:"user_id"`
+	Name         string     `json:"name"`
+	QuestionID   string     `json:"question_id"`
+	QuestionText string     `json:"question_text"`
+	Answered     bool       `json:"answered"`
+	Answer       string     `json:"answer"`
+	Correct      bool       `json:"correct"`
+	Points       int        `json:"points"`
+	AnsweredAt   *time.Time `json:"answered_at,omitempty"`
+	TimeTakenMs  *int64     `json:"time_taken_ms,omitempty"`
+	ResponseMs   *int64     `json:"response_ms,omitempty"`
+}
+
+// ResultRowHeader is the CSV header matching ResultRow.CSVRecord
+var ResultRowHeader = []string{
+	"position", "user_id", "name", "question_id", "question_text", "answered",
+	"answer", "correct", "points", "answered_at", "time_taken_ms", "response_ms",
+}
+
+// CSVRecord formats the row for CSV export
+func (r ResultRow) CSVRecord() []string {
+	answeredAt := ""
+	if r.AnsweredAt != nil {
+		answeredAt = r.AnsweredAt.Format(time.RFC3339Nano)
+	}
+
+	position := ""
+	if r.Position != nil {
+		position = strconv.Itoa(*r.Position)
+	}
+
+	return []string{
+		position, r.UserID, r.Name, r.QuestionID, r.QuestionText, strconv.FormatBool(r.Answered),
+		r.Answer, strconv.FormatBool(r.Correct), strconv.Itoa(r.Points), answeredAt,
+		formatMs(r.TimeTakenMs), formatMs(r.ResponseMs),
+	}
+}
+
+// LeaderboardHeader is the CSV header matching LeaderboardEntry.CSVRecord
+var LeaderboardHeader = []string{"position", "user_id", "name", "score", "avg_response_ms"}
+
+// CSVRecord formats the leaderboard entry for CSV export
+func (e LeaderboardEntry) CSVRecord() []string {
+	return []string{strconv.Itoa(e.Position), e.UserID, e.Name, strconv.Itoa(e.Score), formatMs(e.AvgResponseMs)}
+}
+
+// formatMs renders an optional millisecond count, empty when unknown
+func formatMs(ms *int64) string {
+	if ms == nil {
+		return ""
+	}
+	return strconv.FormatInt(*ms, 10)
+}
+
+// DescribeAnswer renders a recorded answer in human-readable form, using option text where possible
+func (q *Question) DescribeAnswer(answer Answer) string {
+	switch q.GetType() {
+	case QuestionTypeFreeText:
+		return answer.Text
+	case QuestionTypeNumeric:
+		if answer.Number == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*answer.Number, 'f', -1, 64)
+	case QuestionTypeMultiSelect, QuestionTypeOrdering:
+		parts := make([]string, 0, len(answer.Choices))
+		for _, index := range answer.Choices {
+			parts = append(parts, q.optionText(index))
+		}
+		return strings.Join(parts, " | ")
+	}
+	return q.optionText(answer.Answer)
+}
+
+func (q *Question) optionText(index int) string {
+	if index >= 0 && index < len(q.Options) {
+		return q.Options[index]
+	}
+	return strconv.Itoa(index)
+}
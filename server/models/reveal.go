@@ -0,0 +1,186 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// QuestionResults is revealed to everyone when a question closes
+type QuestionResults struct {
+	QuizID         string          `json:"quiz_id"`
+	QuestionID     string          `json:"question_id"`
+	CorrectAnswer  string          `json:"correct_answer"`
+	CorrectOption  *int            `json:"correct_option,omitempty"`  // Single choice and true/false
+	CorrectOptions []int           `json:"correct_options,omitempty"` // Multi-select set or ordering sequence
+	Options        []OptionResult  `json:"options,omitempty"`
+	Participants   int             `json:"participants"`
+	Responses      int             `json:"responses"`
+	CorrectCount   int             `json:"correct_count"`
+	PercentCorrect float64         `json:"percent_correct"` // Of all participants, so silence counts as wrong
+	Fastest        []FastResponder `json:"fastest_correct"`
+}
+
+// OptionResult is how many participants picked an option. Options are in canonical order
+// and carry their text, since participants may have seen them shuffled.
+type OptionResult struct {
+	Index   int    `json:"index"`
+	Text    string `json:"text"`
+	Count   int    `json:"count"`
+	Correct bool   `json:"correct"`
+}
+
+// FastResponder is one of the quickest correct answers to a question
+type FastResponder struct {
+	UserID     string    `json:"user_id"`
+	Name       string    `json:"name"`
+	AnsweredAt time.Time `json:"answered_at"`
+	ResponseMs *int64    `json:"response_ms,omitempty"` // Since the question was shown, when known
+}
+
+// PersonalResult tells one participant how they did on a closed question
+type PersonalResult struct {
+	QuizID        string          `json:"quiz_id"`
+	QuestionID    string          `json:"question_id"`
+	Answered      bool            `json:"answered"`
+	Correct       bool            `json:"correct"`
+	Points        int             `json:"points"`
+	Breakdown     *ScoreBreakdown `json:"breakdown,omitempty"`
+	Score         int             `json:"score"`
+	CorrectAnswer string          `json:"correct_answer"`
+}
+
+// QuestionResults tallies the answers to a question, listing up to fastest correct responders
+func (q *Quiz) QuestionResults(question *Question, fastest int) QuestionResults {
+	participants := q.GetParticipants()
+
+	results := QuestionResults{
+		QuizID:        q.ID,
+		QuestionID:    question.ID,
+		CorrectAnswer: question.CorrectAnswer(),
+		Participants:  len(participants),
+		Fastest:       make([]FastResponder, 0, fastest),
+	}
+
+	switch question.GetType() {
+	case QuestionTypeSingleChoice, QuestionTypeTrueFalse:
+		correct := question.Correct
+		results.CorrectOption = &correct
+	case QuestionTypeMultiSelect:
+		results.CorrectOptions = question.CorrectSet
+	case QuestionTypeOrdering:
+		results.CorrectOptions = question.CorrectOrder
+	}
+
+	counts := make([]int, len(question.Options))
+	correctResponders := make([]FastResponder, 0)
+	for _, user := range participants {
+		answer, ok := user.GetAnswer(question.ID)
+		if !ok || answer.Skipped {
+			continue
+		}
+
+		results.Responses++
+		question.countChoices(answer, counts)
+		if answer.Correct {
+			results.CorrectCount++
+			correctResponders = append(correctResponders, FastResponder{
+				UserID:     user.ID,
+				Name:       user.Name,
+				AnsweredAt: answer.AnsweredAt,
+				ResponseMs: answer.ResponseMs,
+			})
+		}
+	}
+
+	if question.UsesOptions() && question.GetType() != QuestionTypeOrdering {
+		results.Options = make([]OptionResult, len(question.Options))
+		for i, text := range question.Options {
+			results.Options[i] = OptionResult{
+				Index:   i,
+				Text:    text,
+				Count:   counts[i],
+				Correct: question.IsCorrectOption(i),
+			}
+		}
+	}
+
+	if results.Participants > 0 {
+		percent := float64(results.CorrectCount) * 100 / float64(results.Participants)
+		results.PercentCorrect = math.Round(percent*10) / 10
+	}
+
+	sort.Slice(correctResponders, func(i, j int) bool {
+		return correctResponders[i].AnsweredAt.Before(correctResponders[j].AnsweredAt)
+	})
+	for i := 0; i < len(correctResponders) && i < fastest; i++ {
+		results.Fastest = append(results.Fastest, correctResponders[i])
+	}
+	return results
+}
+
+// PersonalResult returns how the user did on a question
+func (q *Quiz) PersonalResult(question *Question, user *User) PersonalResult {
+	result := PersonalResult{
+		QuizID:        q.ID,
+		QuestionID:    question.ID,
+		Score:         user.GetScore(),
+		CorrectAnswer: question.CorrectAnswer(),
+	}
+	if answer, ok := user.GetAnswer(question.ID); ok {
+		result.Answered = !answer.Skipped
+		result.Correct = answer.Correct
+		result.Points = answer.Points
+		result.Breakdown = answer.Breakdown
+	}
+	return result
+}
+
+// CorrectAnswer renders the question's correct answer in human-readable form
+func (q *Question) CorrectAnswer() string {
+	switch q.GetType() {
+	case QuestionTypeFreeText:
+		if len(q.AcceptedAnswers) > 0 {
+			return q.AcceptedAnswers[0]
+		}
+		return ""
+	case QuestionTypeNumeric:
+		return q.DescribeAnswer(Answer{Number: q.CorrectNumber})
+	case QuestionTypeMultiSelect:
+		return q.DescribeAnswer(Answer{Choices: q.CorrectSet})
+	case QuestionTypeOrdering:
+		return q.DescribeAnswer(Answer{Choices: q.CorrectOrder})
+	}
+	return q.DescribeAnswer(Answer{Answer: q.Correct})
+}
+
+// IsCorrectOption reports whether picking the option is (part of) the correct answer
+func (q *Question) IsCorrectOption(index int) bool {
+	switch q.GetType() {
+	case QuestionTypeSingleChoice, QuestionTypeTrueFalse:
+		return index == q.Correct
+	case QuestionTypeMultiSelect:
+		for _, correct := range q.CorrectSet {
+			if correct == index {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// countChoices adds the options picked in an answer to counts
+func (q *Question) countChoices(answer Answer, counts []int) {
+	switch q.GetType() {
+	case QuestionTypeSingleChoice, QuestionTypeTrueFalse:
+		if answer.Answer >= 0 && answer.Answer < len(counts) {
+			counts[answer.Answer]++
+		}
+	case QuestionTypeMultiSelect:
+		for _, index := range answer.Choices {
+			if index >= 0 && index < len(counts) {
+				counts[index]++
+			}
+		}
+	}
+}
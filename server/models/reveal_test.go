@@ -0,0 +1,196 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQuestionResults(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return base.Add(time.Duration(seconds) * time.Second) }
+
+	single := &Question{ID: "q1", Options: []string{"a", "b", "c"}, Correct: 1}
+	multi := &Question{ID: "q2", Type: QuestionTypeMultiSelect, Options: []string{"a", "b", "c"}, CorrectSet: []int{0, 2}}
+
+	tests := []struct {
+		name        string
+		question    *Question
+		answers     map[string]Answer
+		fastest     int
+		wantCounts  []int
+		wantCorrect []bool
+		wantResp    int
+		wantRight   int
+		wantPercent float64
+		wantFastest []string
+	}{
+		{
+			name:     "single choice",
+			question: single,
+			answers: map[string]Answer{
+				"u1": {Answer: 1, Correct: true, AnsweredAt: at(5)},
+				"u2": {Answer: 1, Correct: true, AnsweredAt: at(2)},
+				"u3": {Answer: 0, AnsweredAt: at(1)},
+			},
+			fastest:     3,
+			wantCounts:  []int{1, 2, 0},
+			wantCorrect: []bool{false, true, false},
+			wantResp:    3,
+			wantRight:   2,
+			wantPercent: 50,
+			wantFastest: []string{"u2", "u1"},
+		},
+		{
+			name:     "skips and silence count against the percentage",
+			question: single,
+			answers: map[string]Answer{
+				"u1": {Answer: 1, Correct: true, AnsweredAt: at(5)},
+				"u2": {Skipped: true, AnsweredAt: at(30)},
+			},
+			fastest:     3,
+			wantCounts:  []int{0, 1, 0},
+			wantCorrect: []bool{false, true, false},
+			wantResp:    1,
+			wantRight:   1,
+			wantPercent: 25,
+			wantFastest: []string{"u1"},
+		},
+		{
+			name:     "multi-select counts every pick",
+			question: multi,
+			answers: map[string]Answer{
+				"u1": {Choices: []int{0, 2}, Correct: true, AnsweredAt: at(3)},
+				"u2": {Choices: []int{0, 1}, AnsweredAt: at(4)},
+				"u3": {Choices: []int{2, 0}, Correct: true, AnsweredAt: at(1)},
+			},
+			fastest:     1,
+			wantCounts:  []int{3, 1, 2},
+			wantCorrect: []bool{true, false, true},
+			wantResp:    3,
+			wantRight:   2,
+			wantPercent: 50,
+			wantFastest: []string{"u3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{ID: "quiz", Participants: make(map[string]*User)}
+			for _, id := range []string{"u1", "u2", "u3", "u4"} {
+				quiz.Participants[id] = &User{ID: id, Name: id}
+			}
+			for id, answer := range tt.answers {
+				answer.QuestionID = tt.question.ID
+				quiz.Participants[id].AddAnswer(answer)
+			}
+
+			results := quiz.QuestionResults(tt.question, tt.fastest)
+
+			if results.Participants != 4 || results.Responses != tt.wantResp || results.CorrectCount != tt.wantRight {
+				t.Errorf("participants/responses/correct = %d/%d/%d, want 4/%d/%d",
+					results.Participants, results.Responses, results.CorrectCount, tt.wantResp, tt.wantRight)
+			}
+			if results.PercentCorrect != tt.wantPercent {
+				t.Errorf("percent correct = %v, want %v", results.PercentCorrect, tt.wantPercent)
+			}
+
+			counts := make([]int, len(results.Options))
+			correct := make([]bool, len(results.Options))
+			for i, option := range results.Options {
+				counts[i], correct[i] = option.Count, option.Correct
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) || !reflect.DeepEqual(correct, tt.wantCorrect) {
+				t.Errorf("option counts %v correct %v, want %v %v", counts, correct, tt.wantCounts, tt.wantCorrect)
+			}
+
+			fastest := []string{}
+			for _, responder := range results.Fastest {
+				fastest = append(fastest, responder.UserID)
+			}
+			if !reflect.DeepEqual(fastest, tt.wantFastest) {
+				t.Errorf("fastest = %v, want %v", fastest, tt.wantFastest)
+			}
+		})
+	}
+}
+
+func TestQuestionResultsCorrectAnswer(t *testing.T) {
+	tests := []struct {
+		name        string
+		question    *Question
+		wantOption  *int
+		wantOptions []int
+		wantOptionN int
+	}{
+		{"single choice", &Question{ID: "q1", Options: []string{"a", "b"}, Correct: 1}, intPtr(1), nil, 2},
+		{"true/false", &Question{ID: "q1", Type: QuestionTypeTrueFalse, Options: []string{"True", "False"}}, intPtr(0), nil, 2},
+		{"multi-select", &Question{ID: "q1", Type: QuestionTypeMultiSelect, Options: []string{"a", "b"}, CorrectSet: []int{1}}, nil, []int{1}, 2},
+		{"ordering has no option tally", &Question{ID: "q1", Type: QuestionTypeOrdering, Options: []string{"a", "b"}, CorrectOrder: []int{1, 0}}, nil, []int{1, 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{ID: "quiz", Participants: make(map[string]*User)}
+			results := quiz.QuestionResults(tt.question, 3)
+
+			if !reflect.DeepEqual(results.CorrectOption, tt.wantOption) {
+				t.Errorf("correct option = %v, want %v", results.CorrectOption, tt.wantOption)
+			}
+			if !reflect.DeepEqual(results.CorrectOptions, tt.wantOptions) {
+				t.Errorf("correct options = %v, want %v", results.CorrectOptions, tt.wantOptions)
+			}
+			if len(results.Options) != tt.wantOptionN {
+				t.Errorf("%d option results, want %d", len(results.Options), tt.wantOptionN)
+			}
+			if results.PercentCorrect != 0 {
+				t.Errorf("percent correct without participants = %v, want 0", results.PercentCorrect)
+			}
+		})
+	}
+}
+
+func TestPersonalResult(t *testing.T) {
+	question := &Question{ID: "q1", Options: []string{"a", "b"}, Correct: 1}
+
+	tests := []struct {
+		name         string
+		answer       *Answer
+		wantAnswered bool
+		wantCorrect  bool
+		wantPoints   int
+	}{
+		{"correct", &Answer{Answer: 1, Correct: true, Points: 10}, true, true, 10},
+		{"wrong", &Answer{Answer: 0}, true, false, 0},
+		{"skipped", &Answer{Skipped: true}, false, false, 0},
+		{"no answer", nil, false, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1", Score: 5}
+			if tt.answer != nil {
+				tt.answer.QuestionID = question.ID
+				user.AddAnswer(*tt.answer)
+			}
+			quiz := &Quiz{ID: "quiz"}
+
+			result := quiz.PersonalResult(question, user)
+
+			if result.Answered != tt.wantAnswered || result.Correct != tt.wantCorrect || result.Points != tt.wantPoints {
+				t.Errorf("answered/correct/points = %v/%v/%d, want %v/%v/%d",
+					result.Answered, result.Correct, result.Points, tt.wantAnswered, tt.wantCorrect, tt.wantPoints)
+			}
+			if result.Score != 5+tt.wantPoints {
+				t.Errorf("score = %d, want %d", result.Score, 5+tt.wantPoints)
+			}
+			if result.CorrectAnswer != question.CorrectAnswer() {
+				t.Errorf("correct answer = %q, want %q", result.CorrectAnswer, question.CorrectAnswer())
+			}
+		})
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
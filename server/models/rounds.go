@@ -0,0 +1,343 @@
+package models
+
+import (
+	"fmt"
+	"math"
+)
+
+// Round is an ordered group of questions played back to back. After its last question
+// the quiz stops for an intermission until the host starts the next round.
+type Round struct {
+	Title       string   `json:"title"`
+	QuestionIDs []string `json:"question_ids"`
+	Multiplier  float64  `json:"multiplier,omitempty"`   // Scales the round's points, defaults to 1
+	TimeLimit   int      `json:"time_limit,omitempty"`   // Seconds, overrides the quiz default
+	AutoAdvance *bool    `json:"auto_advance,omitempty"` // Overrides the quiz setting
+}
+
+// RoundSummary describes a round without revealing which questions it holds
+type RoundSummary struct {
+	Index         int     `json:"index"`
+	Title         string  `json:"title"`
+	Multiplier    float64 `json:"multiplier"`
+	QuestionCount int     `json:"question_count"`
+	RoundCount    int     `json:"round_count"`
+}
+
+// RoundResults is broadcast as round_ended at each round boundary
+type RoundResults struct {
+	QuizID           string             `json:"quiz_id"`
+	Round            RoundSummary       `json:"round"`
+	NextRound        *RoundSummary      `json:"next_round,omitempty"`
+	RoundLeaderboard []LeaderboardEntry `json:"round_leaderboard"`
+	Leaderboard      []LeaderboardEntry `json:"leaderboard"`
+}
+
+// GetMultiplier returns the round's point multiplier, defaulting to 1
+func (r Round) GetMultiplier() float64 {
+	if r.Multiplier == 0 {
+		return 1
+	}
+	return r.Multiplier
+}
+
+// ApplyMultiplier scales points by the multiplier, rounding to the nearest point
+func (r Round) ApplyMultiplier(points int) int {
+	return int(math.Round(float64(points) * r.GetMultiplier()))
+}
+
+// CloneRounds deep-copies rounds so that quizzes and templates never share question ID lists
+func CloneRounds(rounds []Round) []Round {
+	if rounds == nil {
+		return nil
+	}
+	cloned := make([]Round, len(rounds))
+	for i, round := range rounds {
+		round.QuestionIDs = append([]string(nil), round.QuestionIDs...)
+		if round.AutoAdvance != nil {
+			autoAdvance := *round.AutoAdvance
+			round.AutoAdvance = &autoAdvance
+		}
+		cloned[i] = round
+	}
+	return cloned
+}
+
+// ArrangeRounds validates rounds against the questions and returns the questions in round order.
+// Every question must belong to exactly one round. Without rounds the questions are unchanged.
+func ArrangeRounds(rounds []Round, questions []Question) ([]Question, error) {
+	if len(rounds) == 0 {
+		return questions, nil
+	}
+
+	byID := make(map[string]Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	arranged := make([]Question, 0, len(questions))
+	seen := make(map[string]bool, len(questions))
+	for i, round := range rounds {
+		if round.Title == "" {
+			return nil, fmt.Errorf("round %d: title is required", i+1)
+		}
+		if len(round.QuestionIDs) == 0 {
+			return nil, fmt.Errorf("round %d: at least one question is required", i+1)
+		}
+		if round.Multiplier < 0 {
+			return nil, fmt.Errorf("round %d: multiplier must not be negative", i+1)
+		}
+		if round.TimeLimit < 0 {
+			return nil, fmt.Errorf("round %d: time limit must not be negative", i+1)
+		}
+
+		for _, questionID := range round.QuestionIDs {
+			question, exists := byID[questionID]
+			if !exists {
+				return nil, fmt.Errorf("round %d: question not found: %s", i+1, questionID)
+			}
+			if seen[questionID] {
+				return nil, fmt.Errorf("round %d: question %s is already in a round", i+1, questionID)
+			}
+			seen[questionID] = true
+			arranged = append(arranged, question)
+		}
+	}
+
+	if len(arranged) != len(questions) {
+		return nil, fmt.Errorf("every question must belong to a round")
+	}
+	return arranged, nil
+}
+
+// SyncRounds fits rounds to an edited question list: removed questions leave their round,
+// rounds left empty are dropped, new questions join the last round, and questions keep the
+// order they have within each round. It returns the rounds and the questions in round order.
+func SyncRounds(rounds []Round, questions []Question) ([]Round, []Question) {
+	if len(rounds) == 0 {
+		return rounds, questions
+	}
+
+	position := make(map[string]int, len(questions))
+	for i, question := range questions {
+		position[question.ID] = i
+	}
+
+	assigned := make(map[string]bool, len(questions))
+	synced := make([]Round, 0, len(rounds))
+	for _, round := range rounds {
+		ids := make([]string, 0, len(round.QuestionIDs))
+		for _, questionID := range round.QuestionIDs {
+			if _, exists := position[questionID]; exists && !assigned[questionID] {
+				ids = append(ids, questionID)
+				assigned[questionID] = true
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		round.QuestionIDs = ids
+		synced = append(synced, round)
+	}
+
+	for _, question := range questions {
+		if assigned[question.ID] {
+			continue
+		}
+		if len(synced) == 0 {
+			synced = append(synced, Round{Title: "Round 1"})
+		}
+		last := &synced[len(synced)-1]
+		last.QuestionIDs = append(last.QuestionIDs, question.ID)
+	}
+
+	// Within a round, follow the order of the edited question list
+	arranged := make([]Question, 0, len(questions))
+	for i := range synced {
+		ids := synced[i].QuestionIDs
+		sortByPosition(ids, position)
+		for _, questionID := range ids {
+			arranged = append(arranged, questions[position[questionID]])
+		}
+	}
+	return synced, arranged
+}
+
+func sortByPosition(ids []string, position map[string]int) {
+	for i := 1; i < len(ids); i++ {
+		for j := i; j > 0 && position[ids[j]] < position[ids[j-1]]; j-- {
+			ids[j], ids[j-1] = ids[j-1], ids[j]
+		}
+	}
+}
+
+// roundIndexOf returns the index of the round holding the question, or -1; the caller holds q.mu
+func (q *Quiz) roundIndexOf(questionID string) int {
+	for i, round := range q.Rounds {
+		for _, id := range round.QuestionIDs {
+			if id == questionID {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// roundOf returns the round holding the question, if any; the caller holds q.mu
+func (q *Quiz) roundOf(questionID string) *Round {
+	if index := q.roundIndexOf(questionID); index >= 0 {
+		return &q.Rounds[index]
+	}
+	return nil
+}
+
+// roundSummary describes the round at index; the caller holds q.mu
+func (q *Quiz) roundSummary(index int) *RoundSummary {
+	if index < 0 || index >= len(q.Rounds) {
+		return nil
+	}
+	round := q.Rounds[index]
+	return &RoundSummary{
+		Index:         index,
+		Title:         round.Title,
+		Multiplier:    round.GetMultiplier(),
+		QuestionCount: len(round.QuestionIDs),
+		RoundCount:    len(q.Rounds),
+	}
+}
+
+// HasRounds reports whether the quiz is split into rounds
+func (q *Quiz) HasRounds() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return len(q.Rounds) > 0
+}
+
+// CurrentRound describes the round of the current question, or returns nil without rounds
+func (q *Quiz) CurrentRound() *RoundSummary {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.CurrentQuestion >= len(q.Questions) {
+		return nil
+	}
+	return q.roundSummary(q.roundIndexOf(q.Questions[q.CurrentQuestion].ID))
+}
+
+// RoundPoints applies the multiplier of the question's round to points
+func (q *Quiz) RoundPoints(questionID string, points int) int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if round := q.roundOf(questionID); round != nil {
+		return round.ApplyMultiplier(points)
+	}
+	return points
+}
+
+// TimeLimitFor returns the question's time limit: its own, else its round's, else the quiz default
+func (q *Quiz) TimeLimitFor(question *Question) int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.timeLimitFor(question)
+}
+
+func (q *Quiz) timeLimitFor(question *Question) int {
+	if question.TimeLimit == 0 {
+		if round := q.roundOf(question.ID); round != nil && round.TimeLimit > 0 {
+			return round.TimeLimit
+		}
+	}
+	return question.GetTimeLimit(q.Settings)
+}
+
+// AutoAdvances reports whether the question moves on by itself, per its round or the quiz setting
+func (q *Quiz) AutoAdvances(questionID string) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if round := q.roundOf(questionID); round != nil && round.AutoAdvance != nil {
+		return *round.AutoAdvance
+	}
+	return q.Settings.AutoAdvance
+}
+
+// IsRoundBoundary reports whether moving from the current question to index crosses into another round
+func (q *Quiz) IsRoundBoundary(index int) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if len(q.Rounds) == 0 || index < 0 || index >= len(q.Questions) || q.CurrentQuestion >= len(q.Questions) {
+		return false
+	}
+	return q.roundIndexOf(q.Questions[index].ID) != q.roundIndexOf(q.Questions[q.CurrentQuestion].ID)
+}
+
+// StartIntermission stops the quiz between rounds; the current question has already been closed
+func (q *Quiz) StartIntermission() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.Intermission = true
+	q.QuestionOpenedAt, q.QuestionDeadline = nil, nil
+}
+
+// InIntermission reports whether the quiz is waiting for the host to start the next round
+func (q *Quiz) InIntermission() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.Intermission
+}
+
+// EndIntermission moves to the first question of the next round; it returns false if there is none
+func (q *Quiz) EndIntermission() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if !q.Intermission || q.CurrentQuestion+1 >= len(q.Questions) {
+		return false
+	}
+	q.Intermission = false
+	q.CurrentQuestion++
+	return true
+}
+
+// RoundResults builds the round_ended message for the round of the current question
+func (q *Quiz) RoundResults() RoundResults {
+	leaderboard := q.GetLeaderboard()
+
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	index := -1
+	if q.CurrentQuestion < len(q.Questions) {
+		index = q.roundIndexOf(q.Questions[q.CurrentQuestion].ID)
+	}
+
+	results := RoundResults{
+		QuizID:      q.ID,
+		NextRound:   q.roundSummary(index + 1),
+		Leaderboard: leaderboard,
+	}
+	if summary := q.roundSummary(index); summary != nil {
+		results.Round = *summary
+	}
+
+	// Round-only scores add up the points earned on the round's questions
+	var questionIDs []string
+	if index >= 0 {
+		questionIDs = q.Rounds[index].QuestionIDs
+	}
+	entries := make([]LeaderboardEntry, 0, len(leaderboard))
+	for _, entry := range leaderboard {
+		user, exists := q.Participants[entry.UserID]
+		if !exists {
+			continue
+		}
+		score := 0
+		for _, questionID := range questionIDs {
+			if answer, ok := user.GetAnswer(questionID); ok {
+				score += answer.Points
+			}
+		}
+		entry := user.leaderboardEntry()
+		entry.Score = score
+		entries = append(entries, entry)
+	}
+	results.RoundLeaderboard = rankEntries(entries)
+	return results
+}
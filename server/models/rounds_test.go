@@ -0,0 +1,204 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyMultiplier(t *testing.T) {
+	tests := []struct {
+		name       string
+		multiplier float64
+		points     int
+		want       int
+	}{
+		{"default", 0, 10, 10},
+		{"double", 2, 10, 20},
+		{"half rounds to nearest", 0.5, 15, 8},
+		{"penalties scale too", 2, -5, -10},
+		{"fraction", 1.25, 10, 13},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			round := Round{Multiplier: tt.multiplier}
+			if got := round.ApplyMultiplier(tt.points); got != tt.want {
+				t.Errorf("ApplyMultiplier(%d) = %d, want %d", tt.points, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundPoints(t *testing.T) {
+	quiz := &Quiz{
+		Questions: []Question{{ID: "q1"}, {ID: "q2"}, {ID: "q3"}},
+		Rounds: []Round{
+			{Title: "Warm-up", QuestionIDs: []string{"q1"}},
+			{Title: "Final", QuestionIDs: []string{"q2", "q3"}, Multiplier: 3},
+		},
+	}
+
+	tests := []struct {
+		questionID string
+		want       int
+	}{
+		{"q1", 10},
+		{"q2", 30},
+		{"q3", 30},
+		{"unknown", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.questionID, func(t *testing.T) {
+			if got := quiz.RoundPoints(tt.questionID, 10); got != tt.want {
+				t.Errorf("RoundPoints(%s, 10) = %d, want %d", tt.questionID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrangeRounds(t *testing.T) {
+	questions := []Question{{ID: "q1"}, {ID: "q2"}, {ID: "q3"}}
+
+	tests := []struct {
+		name    string
+		rounds  []Round
+		want    []string
+		wantErr bool
+	}{
+		{"no rounds", nil, []string{"q1", "q2", "q3"}, false},
+		{
+			name:   "round order",
+			rounds: []Round{{Title: "A", QuestionIDs: []string{"q3"}}, {Title: "B", QuestionIDs: []string{"q2", "q1"}}},
+			want:   []string{"q3", "q2", "q1"},
+		},
+		{"missing title", []Round{{QuestionIDs: []string{"q1", "q2", "q3"}}}, nil, true},
+		{"empty round", []Round{{Title: "A", QuestionIDs: []string{"q1", "q2", "q3"}}, {Title: "B"}}, nil, true},
+		{"negative multiplier", []Round{{Title: "A", QuestionIDs: []string{"q1", "q2", "q3"}, Multiplier: -1}}, nil, true},
+		{"negative time limit", []Round{{Title: "A", QuestionIDs: []string{"q1", "q2", "q3"}, TimeLimit: -1}}, nil, true},
+		{"unknown question", []Round{{Title: "A", QuestionIDs: []string{"q1", "q2", "q3", "q4"}}}, nil, true},
+		{"question in two rounds", []Round{{Title: "A", QuestionIDs: []string{"q1", "q2"}}, {Title: "B", QuestionIDs: []string{"q2", "q3"}}}, nil, true},
+		{"question left out", []Round{{Title: "A", QuestionIDs: []string{"q1", "q2"}}}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arranged, err := ArrangeRounds(tt.rounds, questions)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ArrangeRounds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := questionIDs(arranged); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ArrangeRounds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncRounds(t *testing.T) {
+	rounds := []Round{
+		{Title: "A", QuestionIDs: []string{"q1", "q2"}, Multiplier: 2},
+		{Title: "B", QuestionIDs: []string{"q3"}},
+	}
+
+	tests := []struct {
+		name          string
+		questions     []string
+		wantRounds    [][]string
+		wantQuestions []string
+	}{
+		{
+			name:          "unchanged",
+			questions:     []string{"q1", "q2", "q3"},
+			wantRounds:    [][]string{{"q1", "q2"}, {"q3"}},
+			wantQuestions: []string{"q1", "q2", "q3"},
+		},
+		{
+			name:          "removed question leaves its round",
+			questions:     []string{"q2", "q3"},
+			wantRounds:    [][]string{{"q2"}, {"q3"}},
+			wantQuestions: []string{"q2", "q3"},
+		},
+		{
+			name:          "empty round dropped",
+			questions:     []string{"q1", "q2"},
+			wantRounds:    [][]string{{"q1", "q2"}},
+			wantQuestions: []string{"q1", "q2"},
+		},
+		{
+			name:          "new question joins the last round",
+			questions:     []string{"q4", "q1", "q2", "q3"},
+			wantRounds:    [][]string{{"q1", "q2"}, {"q4", "q3"}},
+			wantQuestions: []string{"q1", "q2", "q4", "q3"},
+		},
+		{
+			name:          "reordered within a round",
+			questions:     []string{"q2", "q3", "q1"},
+			wantRounds:    [][]string{{"q2", "q1"}, {"q3"}},
+			wantQuestions: []string{"q2", "q1", "q3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions := make([]Question, len(tt.questions))
+			for i, id := range tt.questions {
+				questions[i] = Question{ID: id}
+			}
+
+			synced, arranged := SyncRounds(CloneRounds(rounds), questions)
+
+			gotRounds := make([][]string, len(synced))
+			for i, round := range synced {
+				gotRounds[i] = round.QuestionIDs
+			}
+			if !reflect.DeepEqual(gotRounds, tt.wantRounds) {
+				t.Errorf("rounds = %v, want %v", gotRounds, tt.wantRounds)
+			}
+			if got := questionIDs(arranged); !reflect.DeepEqual(got, tt.wantQuestions) {
+				t.Errorf("questions = %v, want %v", got, tt.wantQuestions)
+			}
+			if synced[0].Multiplier != 2 {
+				t.Errorf("first round multiplier = %v, want 2", synced[0].Multiplier)
+			}
+		})
+	}
+}
+
+func TestTimeLimitFor(t *testing.T) {
+	quiz := &Quiz{
+		Settings: QuizSettings{TimeLimit: 30},
+		Rounds: []Round{
+			{Title: "A", QuestionIDs: []string{"q1", "q2"}, TimeLimit: 10},
+			{Title: "B", QuestionIDs: []string{"q3"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		question Question
+		want     int
+	}{
+		{"round limit", Question{ID: "q1"}, 10},
+		{"question overrides round", Question{ID: "q2", TimeLimit: 5}, 5},
+		{"quiz default", Question{ID: "q3"}, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quiz.TimeLimitFor(&tt.question); got != tt.want {
+				t.Errorf("TimeLimitFor(%s) = %d, want %d", tt.question.ID, got, tt.want)
+			}
+		})
+	}
+}
+
+func questionIDs(questions []Question) []string {
+	ids := make([]string, len(questions))
+	for i, question := range questions {
+		ids[i] = question.ID
+	}
+	return ids
+}
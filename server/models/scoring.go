@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScoringMode decides how a correct answer's speed affects its points
+type ScoringMode string
+
+const (
+	ScoringFixed     ScoringMode = "fixed"      // Full points for every correct answer
+	ScoringTimeDecay ScoringMode = "time_decay" // Points fall from full to a minimum as the answer takes longer
+)
+
+// Time-decay defaults
+const (
+	DefaultMinPointsPercent = 50
+	DefaultDecayTime        = 30 // Seconds, for untimed questions
+)
+
+// GetScoring returns the scoring mode, defaulting to fixed
+func (s QuizSettings) GetScoring() ScoringMode {
+	if s.Scoring == "" {
+		return ScoringFixed
+	}
+	return s.Scoring
+}
+
+// GetMinPointsPercent returns the share of points a correct answer keeps however slow it is
+func (s QuizSettings) GetMinPointsPercent() int {
+	if s.MinPointsPercent == nil {
+		return DefaultMinPointsPercent
+	}
+	return *s.MinPointsPercent
+}
+
+// validateScoring checks the scoring settings
+func (s QuizSettings) validateScoring() error {
+	switch s.GetScoring() {
+	case ScoringFixed, ScoringTimeDecay:
+	default:
+		return fmt.Errorf("unknown scoring mode: %s", s.Scoring)
+	}
+	if percent := s.GetMinPointsPercent(); percent < 0 || percent > 100 {
+		return fmt.Errorf("min points percent must be between 0 and 100")
+	}
+	if s.DecayTime < 0 {
+		return fmt.Errorf("decay time must not be negative")
+	}
+	if s.WrongPenaltyPercent < 0 || s.WrongPenaltyPercent > 100 {
+		return fmt.Errorf("wrong penalty percent must be between 0 and 100")
+	}
+	if s.StreakBonus < 0 || s.MaxStreakBonus < 0 {
+		return fmt.Errorf("streak bonus must not be negative")
+	}
+	return nil
+}
+
+// DecayWindow returns how long a question's points take to decay: its time limit, or the
+// decay time for untimed questions
+func (q *Quiz) DecayWindow(question *Question) time.Duration {
+	limit := q.TimeLimitFor(question)
+	if limit == 0 {
+		limit = q.Settings.DecayTime
+		if limit == 0 {
+			limit = DefaultDecayTime
+		}
+	}
+	return time.Duration(limit) * time.Second
+}
+
+// ResponseTime returns how long after the question was shown to the user the answer came:
+// since the current question opened, or in self-paced quizzes since the user reached it.
+// It returns nil when the open time is unknown.
+func (q *Quiz) ResponseTime(question *Question, user *User, answeredAt time.Time) *time.Duration {
+	var shownAt *time.Time
+	if q.Settings.IsSelfPaced() {
+		user.mu.RLock()
+		shownAt = user.CursorAt
+		user.mu.RUnlock()
+	} else {
+		q.mu.RLock()
+		if current := q.currentQuestion(); current != nil && current.ID == question.ID {
+			shownAt = q.QuestionOpenedAt
+		}
+		q.mu.RUnlock()
+	}
+
+	if shownAt == nil {
+		return nil
+	}
+	response := answeredAt.Sub(*shownAt)
+	if response < 0 {
+		response = 0
+	}
+	return &response
+}
+
+// AverageResponseMs returns the user's mean response time over answers with a known one, or nil
+func (u *User) AverageResponseMs() *int64 {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	var total, count int64
+	for _, answer := range u.Answers {
+		if answer.ResponseMs != nil {
+			total += *answer.ResponseMs
+			count++
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	average := total / count
+	return &average
+}
@@ -0,0 +1,232 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// ScoringStrategy is one step of scoring an answer. A quiz scores answers with a chain of
+// strategies built from its settings; each step adjusts the breakdown left by the previous ones.
+type ScoringStrategy interface {
+	Apply(input ScoringInput, breakdown *ScoreBreakdown)
+}
+
+// ScoringInput is what strategies know about an answer
+type ScoringInput struct {
+	Question *Question
+	Value    AnswerValue
+	Correct  bool
+	Skipped  bool           // No answer before the question closed
+	Response *time.Duration // Since the question was shown, when known
+	Window   time.Duration  // Time-decay window
+	Streak   int            // Consecutive correct answers before this one
+}
+
+// ScoreBreakdown records how an answer's points were reached; Points is the final score
+type ScoreBreakdown struct {
+	Base        int     `json:"base"`                   // The question's points
+	Credit      float64 `json:"credit"`                 // Share of the answer that was right, 0 to 1
+	SpeedLoss   int     `json:"speed_loss,omitempty"`   // Points lost to time decay
+	Streak      int     `json:"streak,omitempty"`       // Consecutive correct answers, including this one
+	StreakBonus int     `json:"streak_bonus,omitempty"` // Points added by the streak multiplier
+	Penalty     int     `json:"penalty,omitempty"`      // Points deducted by negative marking
+	RoundBonus  int     `json:"round_bonus,omitempty"`  // Points added by the round multiplier
+	Points      int     `json:"points"`
+}
+
+// ScoringChain applies strategies in order
+type ScoringChain []ScoringStrategy
+
+// Score runs the chain on a fresh breakdown
+func (c ScoringChain) Score(input ScoringInput) ScoreBreakdown {
+	breakdown := ScoreBreakdown{Base: input.Question.Points}
+	for _, strategy := range c {
+		strategy.Apply(input, &breakdown)
+	}
+	return breakdown
+}
+
+// Apply runs the chain as a single strategy
+func (c ScoringChain) Apply(input ScoringInput, breakdown *ScoreBreakdown) {
+	for _, strategy := range c {
+		strategy.Apply(input, breakdown)
+	}
+}
+
+// FixedScoring awards the question's full points for a correct answer
+type FixedScoring struct{}
+
+func (FixedScoring) Apply(input ScoringInput, breakdown *ScoreBreakdown) {
+	if input.Correct {
+		breakdown.Credit = 1
+		breakdown.Points = breakdown.Base
+	}
+}
+
+// PartialCredit awards a share of the points for partly right multi-select and ordering answers
+type PartialCredit struct{}
+
+func (PartialCredit) Apply(input ScoringInput, breakdown *ScoreBreakdown) {
+	if input.Correct || input.Skipped {
+		return
+	}
+	breakdown.Credit = input.Question.Credit(input.Value)
+	breakdown.Points = int(math.Round(float64(breakdown.Base) * breakdown.Credit))
+}
+
+// TimeDecay scales earned points down with the response time
+type TimeDecay struct {
+	MinPointsPercent int
+}
+
+func (s TimeDecay) Apply(input ScoringInput, breakdown *ScoreBreakdown) {
+	if breakdown.Points <= 0 || input.Response == nil || input.Window <= 0 {
+		return
+	}
+	elapsed := math.Min(math.Max(float64(*input.Response)/float64(input.Window), 0), 1)
+	minimum := float64(breakdown.Points) * float64(s.MinPointsPercent) / 100
+	points := int(math.Round(float64(breakdown.Points) - (float64(breakdown.Points)-minimum)*elapsed))
+	breakdown.SpeedLoss = breakdown.Points - points
+	breakdown.Points = points
+}
+
+// StreakMultiplier adds Bonus of the earned points for every consecutive correct answer
+// before this one, up to Max
+type StreakMultiplier struct {
+	Bonus float64
+	Max   float64
+}
+
+func (s StreakMultiplier) Apply(input ScoringInput, breakdown *ScoreBreakdown) {
+	if !input.Correct {
+		return
+	}
+	breakdown.Streak = input.Streak + 1
+	multiplier := math.Min(s.Bonus*float64(input.Streak), s.Max)
+	breakdown.StreakBonus = int(math.Round(float64(breakdown.Points) * multiplier))
+	breakdown.Points += breakdown.StreakBonus
+}
+
+// NegativeMarking deducts PenaltyPercent of the question's points for a wrong answer, and for
+// a skipped one unless SkipsFree is set. Partly right answers are not penalized.
+type NegativeMarking struct {
+	PenaltyPercent int
+	SkipsFree      bool
+}
+
+func (s NegativeMarking) Apply(input ScoringInput, breakdown *ScoreBreakdown) {
+	if breakdown.Credit > 0 || (input.Skipped && s.SkipsFree) {
+		return
+	}
+	breakdown.Penalty = int(math.Round(float64(breakdown.Base) * float64(s.PenaltyPercent) / 100))
+	breakdown.Points -= breakdown.Penalty
+}
+
+// ScoringStrategy builds the quiz's scoring chain from its settings
+func (s QuizSettings) ScoringStrategy() ScoringChain {
+	chain := ScoringChain{FixedScoring{}}
+	if s.PartialCredit {
+		chain = append(chain, PartialCredit{})
+	}
+	if s.GetScoring() == ScoringTimeDecay {
+		chain = append(chain, TimeDecay{MinPointsPercent: s.GetMinPointsPercent()})
+	}
+	if s.StreakBonus > 0 {
+		chain = append(chain, StreakMultiplier{Bonus: s.StreakBonus, Max: s.GetMaxStreakBonus()})
+	}
+	if s.WrongPenaltyPercent > 0 {
+		chain = append(chain, NegativeMarking{PenaltyPercent: s.WrongPenaltyPercent, SkipsFree: s.NoSkipPenalty})
+	}
+	return chain
+}
+
+// PenalizesSkips reports whether unanswered questions cost points
+func (s QuizSettings) PenalizesSkips() bool {
+	return s.WrongPenaltyPercent > 0 && !s.NoSkipPenalty
+}
+
+// GetMaxStreakBonus returns the cap on the streak bonus share, defaulting to doubling the points
+func (s QuizSettings) GetMaxStreakBonus() float64 {
+	if s.MaxStreakBonus == 0 {
+		return 1
+	}
+	return s.MaxStreakBonus
+}
+
+// ScoreAnswer scores an answer with the quiz's strategies and its round multiplier
+func (q *Quiz) ScoreAnswer(input ScoringInput) ScoreBreakdown {
+	input.Window = q.DecayWindow(input.Question)
+	breakdown := q.Settings.ScoringStrategy().Score(input)
+
+	points := q.RoundPoints(input.Question.ID, breakdown.Points)
+	breakdown.RoundBonus = points - breakdown.Points
+	breakdown.Points = points
+	return breakdown
+}
+
+// SkipAnswer returns the penalized record of a question the user never answered.
+// It returns false when the user did answer or skips cost nothing.
+func (q *Quiz) SkipAnswer(question *Question, user *User, now time.Time) (Answer, bool) {
+	if !q.Settings.PenalizesSkips() || question.IsDeferred() || user.HasAnswered(question.ID) {
+		return Answer{}, false
+	}
+
+	breakdown := q.ScoreAnswer(ScoringInput{Question: question, Skipped: true})
+	return Answer{
+		QuestionID: question.ID,
+		Answer:     -1,
+		Skipped:    true,
+		Points:     breakdown.Points,
+		Breakdown:  &breakdown,
+		AnsweredAt: now,
+	}, true
+}
+
+// Credit returns the share of a multi-part answer that is right: for multi-select, right picks
+// minus wrong picks over the correct set; for ordering, items in the right position.
+// Other question types are all or nothing.
+func (q *Question) Credit(value AnswerValue) float64 {
+	if q.IsCorrect(value) {
+		return 1
+	}
+
+	switch q.GetType() {
+	case QuestionTypeMultiSelect:
+		if len(q.CorrectSet) == 0 {
+			return 0
+		}
+		hits := 0
+		for _, index := range value.Choices {
+			if q.IsCorrectOption(index) {
+				hits++
+			} else {
+				hits--
+			}
+		}
+		return math.Max(float64(hits), 0) / float64(len(q.CorrectSet))
+	case QuestionTypeOrdering:
+		if len(q.CorrectOrder) == 0 {
+			return 0
+		}
+		hits := 0
+		for i := range q.CorrectOrder {
+			if i < len(value.Choices) && value.Choices[i] == q.CorrectOrder[i] {
+				hits++
+			}
+		}
+		return float64(hits) / float64(len(q.CorrectOrder))
+	}
+	return 0
+}
+
+// CurrentStreak counts the user's consecutive correct answers, most recent first
+func (u *User) CurrentStreak() int {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	streak := 0
+	for i := len(u.Answers) - 1; i >= 0 && u.Answers[i].Correct; i-- {
+		streak++
+	}
+	return streak
+}
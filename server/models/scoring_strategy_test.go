@@ -0,0 +1,274 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScoringChain(t *testing.T) {
+	multi := &Question{ID: "q1", Type: QuestionTypeMultiSelect, Options: []string{"a", "b", "c", "d"}, CorrectSet: []int{0, 2}, Points: 100}
+	floor := 50
+
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		input    ScoringInput
+		want     ScoreBreakdown
+	}{
+		{
+			name:  "fixed, correct",
+			input: ScoringInput{Correct: true},
+			want:  ScoreBreakdown{Base: 100, Credit: 1, Points: 100},
+		},
+		{
+			name:  "fixed, wrong",
+			input: ScoringInput{Value: AnswerValue{Choices: []int{0}}},
+			want:  ScoreBreakdown{Base: 100},
+		},
+		{
+			name:     "partial credit",
+			settings: QuizSettings{PartialCredit: true},
+			input:    ScoringInput{Value: AnswerValue{Choices: []int{0}}},
+			want:     ScoreBreakdown{Base: 100, Credit: 0.5, Points: 50},
+		},
+		{
+			name:     "partial credit, wrong pick cancels a right one",
+			settings: QuizSettings{PartialCredit: true},
+			input:    ScoringInput{Value: AnswerValue{Choices: []int{0, 1}}},
+			want:     ScoreBreakdown{Base: 100},
+		},
+		{
+			name:     "time decay",
+			settings: QuizSettings{Scoring: ScoringTimeDecay, MinPointsPercent: &floor},
+			input:    ScoringInput{Correct: true, Response: durationPtr(10 * time.Second), Window: 20 * time.Second},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, SpeedLoss: 25, Points: 75},
+		},
+		{
+			name:     "time decay past the window",
+			settings: QuizSettings{Scoring: ScoringTimeDecay, MinPointsPercent: &floor},
+			input:    ScoringInput{Correct: true, Response: durationPtr(time.Minute), Window: 20 * time.Second},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, SpeedLoss: 50, Points: 50},
+		},
+		{
+			name:     "time decay, response time unknown",
+			settings: QuizSettings{Scoring: ScoringTimeDecay},
+			input:    ScoringInput{Correct: true, Window: 20 * time.Second},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, Points: 100},
+		},
+		{
+			name:     "time decay on partial credit",
+			settings: QuizSettings{PartialCredit: true, Scoring: ScoringTimeDecay, MinPointsPercent: &floor},
+			input:    ScoringInput{Value: AnswerValue{Choices: []int{2}}, Response: durationPtr(20 * time.Second), Window: 20 * time.Second},
+			want:     ScoreBreakdown{Base: 100, Credit: 0.5, SpeedLoss: 25, Points: 25},
+		},
+		{
+			name:     "streak",
+			settings: QuizSettings{StreakBonus: 0.1},
+			input:    ScoringInput{Correct: true, Streak: 3},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, Streak: 4, StreakBonus: 30, Points: 130},
+		},
+		{
+			name:     "streak capped",
+			settings: QuizSettings{StreakBonus: 0.1, MaxStreakBonus: 0.5},
+			input:    ScoringInput{Correct: true, Streak: 9},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, Streak: 10, StreakBonus: 50, Points: 150},
+		},
+		{
+			name:     "streak on decayed points",
+			settings: QuizSettings{Scoring: ScoringTimeDecay, MinPointsPercent: &floor, StreakBonus: 0.1},
+			input:    ScoringInput{Correct: true, Streak: 2, Response: durationPtr(10 * time.Second), Window: 20 * time.Second},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, SpeedLoss: 25, Streak: 3, StreakBonus: 15, Points: 90},
+		},
+		{
+			name:     "wrong answer breaks no streak bonus",
+			settings: QuizSettings{StreakBonus: 0.1},
+			input:    ScoringInput{Streak: 3},
+			want:     ScoreBreakdown{Base: 100},
+		},
+		{
+			name:     "negative marking, wrong",
+			settings: QuizSettings{WrongPenaltyPercent: 25},
+			input:    ScoringInput{Value: AnswerValue{Choices: []int{1}}},
+			want:     ScoreBreakdown{Base: 100, Penalty: 25, Points: -25},
+		},
+		{
+			name:     "negative marking, skipped",
+			settings: QuizSettings{WrongPenaltyPercent: 25},
+			input:    ScoringInput{Skipped: true},
+			want:     ScoreBreakdown{Base: 100, Penalty: 25, Points: -25},
+		},
+		{
+			name:     "negative marking, skips free",
+			settings: QuizSettings{WrongPenaltyPercent: 25, NoSkipPenalty: true},
+			input:    ScoringInput{Skipped: true},
+			want:     ScoreBreakdown{Base: 100},
+		},
+		{
+			name:     "negative marking spares partly right answers",
+			settings: QuizSettings{PartialCredit: true, WrongPenaltyPercent: 25},
+			input:    ScoringInput{Value: AnswerValue{Choices: []int{0}}},
+			want:     ScoreBreakdown{Base: 100, Credit: 0.5, Points: 50},
+		},
+		{
+			name:     "negative marking leaves correct answers alone",
+			settings: QuizSettings{WrongPenaltyPercent: 25},
+			input:    ScoringInput{Correct: true},
+			want:     ScoreBreakdown{Base: 100, Credit: 1, Points: 100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.Question = multi
+			if got := tt.settings.ScoringStrategy().Score(tt.input); got != tt.want {
+				t.Errorf("Score() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreAnswerRoundBonus(t *testing.T) {
+	quiz := &Quiz{
+		Settings:  QuizSettings{WrongPenaltyPercent: 10},
+		Questions: []Question{{ID: "q1", Points: 100}, {ID: "q2", Points: 100}},
+		Rounds: []Round{
+			{Title: "A", QuestionIDs: []string{"q1"}},
+			{Title: "B", QuestionIDs: []string{"q2"}, Multiplier: 2},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		question int
+		correct  bool
+		want     ScoreBreakdown
+	}{
+		{"plain round", 0, true, ScoreBreakdown{Base: 100, Credit: 1, Points: 100}},
+		{"double round", 1, true, ScoreBreakdown{Base: 100, Credit: 1, RoundBonus: 100, Points: 200}},
+		{"double round penalty", 1, false, ScoreBreakdown{Base: 100, Penalty: 10, RoundBonus: -10, Points: -20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := quiz.ScoreAnswer(ScoringInput{Question: &quiz.Questions[tt.question], Correct: tt.correct})
+			if got != tt.want {
+				t.Errorf("ScoreAnswer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSkipAnswer(t *testing.T) {
+	question := &Question{ID: "q1", Options: []string{"a", "b"}, Points: 40}
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		settings   QuizSettings
+		answered   bool
+		want       bool
+		wantPoints int
+	}{
+		{"no negative marking", QuizSettings{}, false, false, 0},
+		{"skips free", QuizSettings{WrongPenaltyPercent: 50, NoSkipPenalty: true}, false, false, 0},
+		{"already answered", QuizSettings{WrongPenaltyPercent: 50}, true, false, 0},
+		{"penalized skip", QuizSettings{WrongPenaltyPercent: 50}, false, true, -20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{Settings: tt.settings, Questions: []Question{*question}}
+			user := &User{ID: "u1"}
+			if tt.answered {
+				user.AddAnswer(Answer{QuestionID: question.ID})
+			}
+
+			answer, ok := quiz.SkipAnswer(question, user, now)
+			if ok != tt.want {
+				t.Fatalf("SkipAnswer() ok = %v, want %v", ok, tt.want)
+			}
+			if ok && (!answer.Skipped || answer.Points != tt.wantPoints || answer.Breakdown == nil) {
+				t.Errorf("SkipAnswer() = %+v, want a skipped answer worth %d", answer, tt.wantPoints)
+			}
+		})
+	}
+}
+
+func TestCredit(t *testing.T) {
+	multi := &Question{Type: QuestionTypeMultiSelect, Options: []string{"a", "b", "c", "d"}, CorrectSet: []int{0, 1, 2}}
+	ordering := &Question{Type: QuestionTypeOrdering, Options: []string{"a", "b", "c"}, CorrectOrder: []int{2, 0, 1}}
+	single := &Question{Options: []string{"a", "b"}, Correct: 1}
+
+	tests := []struct {
+		name     string
+		question *Question
+		value    AnswerValue
+		want     float64
+	}{
+		{"multi-select all right", multi, AnswerValue{Choices: []int{2, 1, 0}}, 1},
+		{"multi-select two of three", multi, AnswerValue{Choices: []int{0, 1}}, 2.0 / 3},
+		{"multi-select wrong pick costs one", multi, AnswerValue{Choices: []int{0, 1, 3}}, 1.0 / 3},
+		{"multi-select never below zero", multi, AnswerValue{Choices: []int{3}}, 0},
+		{"ordering one in place", ordering, AnswerValue{Choices: []int{2, 1, 0}}, 1.0 / 3},
+		{"ordering right", ordering, AnswerValue{Choices: []int{2, 0, 1}}, 1},
+		{"ordering none in place", ordering, AnswerValue{Choices: []int{0, 1, 2}}, 0},
+		{"single choice wrong", single, AnswerValue{Choice: 0}, 0},
+		{"single choice right", single, AnswerValue{Choice: 1}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.Credit(tt.value); got != tt.want {
+				t.Errorf("Credit(%+v) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentStreak(t *testing.T) {
+	tests := []struct {
+		name    string
+		correct []bool
+		want    int
+	}{
+		{"no answers", nil, 0},
+		{"last answer wrong", []bool{true, true, false}, 0},
+		{"run since the last miss", []bool{true, false, true, true}, 2},
+		{"all correct", []bool{true, true, true}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1"}
+			for _, correct := range tt.correct {
+				user.AddAnswer(Answer{Correct: correct})
+			}
+			if got := user.CurrentStreak(); got != tt.want {
+				t.Errorf("CurrentStreak() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateScoringStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		wantErr  bool
+	}{
+		{"negative marking", QuizSettings{WrongPenaltyPercent: 25}, false},
+		{"penalty above 100", QuizSettings{WrongPenaltyPercent: 101}, true},
+		{"negative penalty", QuizSettings{WrongPenaltyPercent: -1}, true},
+		{"streak bonus", QuizSettings{StreakBonus: 0.1, MaxStreakBonus: 2}, false},
+		{"negative streak bonus", QuizSettings{StreakBonus: -0.1}, true},
+		{"negative streak cap", QuizSettings{StreakBonus: 0.1, MaxStreakBonus: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.validateScoring(); (err != nil) != tt.wantErr {
+				t.Errorf("validateScoring() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDecayWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		limit    int
+		want     time.Duration
+	}{
+		{"question time limit", QuizSettings{DecayTime: 60}, 15, 15 * time.Second},
+		{"quiz time limit", QuizSettings{TimeLimit: 20, DecayTime: 60}, 0, 20 * time.Second},
+		{"decay time for untimed questions", QuizSettings{DecayTime: 60}, 0, time.Minute},
+		{"default decay time", QuizSettings{}, 0, DefaultDecayTime * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{Settings: tt.settings}
+			if got := quiz.DecayWindow(&Question{ID: "q1", TimeLimit: tt.limit}); got != tt.want {
+				t.Errorf("DecayWindow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseTime(t *testing.T) {
+	openedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	questions := []Question{{ID: "q1"}, {ID: "q2"}}
+
+	tests := []struct {
+		name       string
+		settings   QuizSettings
+		openedAt   *time.Time
+		cursorAt   *time.Time
+		questionID string
+		answeredAt time.Time
+		want       *time.Duration
+	}{
+		{"current question", QuizSettings{}, &openedAt, nil, "q1", openedAt.Add(3 * time.Second), durationPtr(3 * time.Second)},
+		{"another question", QuizSettings{}, &openedAt, nil, "q2", openedAt.Add(3 * time.Second), nil},
+		{"open time unknown", QuizSettings{}, nil, nil, "q1", openedAt.Add(3 * time.Second), nil},
+		{"clock skew", QuizSettings{}, &openedAt, nil, "q1", openedAt.Add(-time.Second), durationPtr(0)},
+		{"self-paced since reaching the question", QuizSettings{Mode: QuizModeSelfPaced}, &openedAt, timePtr(openedAt.Add(time.Minute)), "q2", openedAt.Add(70 * time.Second), durationPtr(10 * time.Second)},
+		{"self-paced without a cursor time", QuizSettings{Mode: QuizModeSelfPaced}, &openedAt, nil, "q2", openedAt.Add(70 * time.Second), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{Status: QuizStatusActive, Settings: tt.settings, Questions: questions, QuestionOpenedAt: tt.openedAt}
+			user := &User{ID: "u1", CursorAt: tt.cursorAt}
+			question := &questions[0]
+			if tt.questionID == "q2" {
+				question = &questions[1]
+			}
+
+			got := quiz.ResponseTime(question, user, tt.answeredAt)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("ResponseTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAverageResponseMs(t *testing.T) {
+	ms := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name    string
+		answers []Answer
+		want    *int64
+	}{
+		{"no answers", nil, nil},
+		{"no known response times", []Answer{{QuestionID: "q1"}}, nil},
+		{"mean of known times", []Answer{{ResponseMs: ms(1000)}, {QuestionID: "q2"}, {ResponseMs: ms(3000)}}, ms(2000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1", Answers: tt.answers}
+			got := user.AverageResponseMs()
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("AverageResponseMs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateScoring(t *testing.T) {
+	percent := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		settings QuizSettings
+		wantErr  bool
+	}{
+		{"fixed by default", QuizSettings{}, false},
+		{"time decay", QuizSettings{Scoring: ScoringTimeDecay, MinPointsPercent: percent(0), DecayTime: 10}, false},
+		{"unknown mode", QuizSettings{Scoring: "fastest_only"}, true},
+		{"floor above 100", QuizSettings{Scoring: ScoringTimeDecay, MinPointsPercent: percent(101)}, true},
+		{"negative floor", QuizSettings{Scoring: ScoringTimeDecay, MinPointsPercent: percent(-1)}, true},
+		{"negative decay time", QuizSettings{Scoring: ScoringTimeDecay, DecayTime: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.settings.validateScoring(); (err != nil) != tt.wantErr {
+				t.Errorf("validateScoring() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
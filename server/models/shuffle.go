@@ -0,0 +1,87 @@
+package models
+
+import "math/rand"
+
+// CanShuffleOptions reports whether a question's options may be shown in a per-participant order.
+// True/false options keep their natural order.
+func (q *Question) CanShuffleOptions() bool {
+	return q.UsesOptions() && q.GetType() != QuestionTypeTrueFalse
+}
+
+// AssignShuffle gives the user their own question order and option orders according to the quiz settings
+func (u *User) AssignShuffle(questions []Question, settings QuizSettings) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if settings.ShuffleQuestions {
+		u.QuestionOrder = make([]string, 0, len(questions))
+		for _, index := range rand.Perm(len(questions)) {
+			u.QuestionOrder = append(u.QuestionOrder, questions[index].ID)
+		}
+	}
+
+	if settings.ShuffleOptions {
+		u.OptionOrders = make(map[string][]int)
+		for i := range questions {
+			if questions[i].CanShuffleOptions() {
+				u.OptionOrders[questions[i].ID] = rand.Perm(len(questions[i].Options))
+			}
+		}
+	}
+}
+
+// OrderedQuestions returns the questions in the order this user sees them
+func (u *User) OrderedQuestions(questions []Question) []*Question {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	ordered := make([]*Question, 0, len(questions))
+	if len(u.QuestionOrder) != len(questions) {
+		for i := range questions {
+			ordered = append(ordered, &questions[i])
+		}
+		return ordered
+	}
+
+	byID := make(map[string]*Question, len(questions))
+	for i := range questions {
+		byID[questions[i].ID] = &questions[i]
+	}
+	for _, questionID := range u.QuestionOrder {
+		if question, ok := byID[questionID]; ok {
+			ordered = append(ordered, question)
+		}
+	}
+	return ordered
+}
+
+// OptionOrder returns the user's option permutation for a question: position i shows canonical option order[i].
+// It returns nil when the options are shown in their canonical order.
+func (u *User) OptionOrder(question *Question) []int {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	order := u.OptionOrders[question.ID]
+	if len(order) != len(question.Options) {
+		return nil
+	}
+	return order
+}
+
+// CanonicalAnswer maps option indexes the user submitted (in their shuffled order) back to canonical indexes
+func (u *User) CanonicalAnswer(question *Question, value AnswerValue) AnswerValue {
+	order := u.OptionOrder(question)
+	if order == nil {
+		return value
+	}
+
+	value.Choice = order[value.Choice]
+	if value.Choices != nil {
+		choices := make([]int, len(value.Choices))
+		for i, index := range value.Choices {
+			choices[i] = order[index]
+		}
+		value.Choices = choices
+	}
+	return value
+}
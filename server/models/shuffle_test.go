@@ -0,0 +1,163 @@
+package models
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCanShuffleOptions(t *testing.T) {
+	tests := []struct {
+		questionType QuestionType
+		want         bool
+	}{
+		{QuestionTypeSingleChoice, true},
+		{QuestionTypeMultiSelect, true},
+		{QuestionTypeOrdering, true},
+		{QuestionTypeTrueFalse, false},
+		{QuestionTypeFreeText, false},
+		{QuestionTypeNumeric, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.questionType), func(t *testing.T) {
+			question := Question{Type: tt.questionType}
+			if got := question.CanShuffleOptions(); got != tt.want {
+				t.Errorf("CanShuffleOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalAnswer(t *testing.T) {
+	question := &Question{ID: "q1", Options: []string{"a", "b", "c", "d"}}
+
+	tests := []struct {
+		name  string
+		order []int
+		value AnswerValue
+		want  AnswerValue
+	}{
+		{
+			name:  "no shuffle",
+			order: nil,
+			value: AnswerValue{Choice: 2},
+			want:  AnswerValue{Choice: 2},
+		},
+		{
+			name:  "single choice",
+			order: []int{3, 1, 0, 2},
+			value: AnswerValue{Choice: 0},
+			want:  AnswerValue{Choice: 3},
+		},
+		{
+			name:  "choice list",
+			order: []int{3, 1, 0, 2},
+			value: AnswerValue{Choices: []int{0, 2, 3}},
+			want:  AnswerValue{Choice: 3, Choices: []int{3, 0, 2}},
+		},
+		{
+			name:  "order for other option count ignored",
+			order: []int{1, 0},
+			value: AnswerValue{Choice: 1},
+			want:  AnswerValue{Choice: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1"}
+			if tt.order != nil {
+				user.OptionOrders = map[string][]int{question.ID: tt.order}
+			}
+			if got := user.CanonicalAnswer(question, tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CanonicalAnswer(%+v) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrderedQuestions(t *testing.T) {
+	questions := []Question{{ID: "q1"}, {ID: "q2"}, {ID: "q3"}}
+
+	tests := []struct {
+		name  string
+		order []string
+		want  []string
+	}{
+		{"canonical order", nil, []string{"q1", "q2", "q3"}},
+		{"user order", []string{"q3", "q1", "q2"}, []string{"q3", "q1", "q2"}},
+		{"stale order falls back to canonical", []string{"q2", "q1"}, []string{"q1", "q2", "q3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1", QuestionOrder: tt.order}
+			got := []string{}
+			for _, question := range user.OrderedQuestions(questions) {
+				got = append(got, question.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("OrderedQuestions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssignShuffle(t *testing.T) {
+	questions := []Question{
+		{ID: "q1", Options: []string{"a", "b", "c"}},
+		{ID: "q2", Type: QuestionTypeTrueFalse, Options: []string{"True", "False"}},
+		{ID: "q3", Type: QuestionTypeMultiSelect, Options: []string{"a", "b"}},
+	}
+
+	tests := []struct {
+		name          string
+		settings      QuizSettings
+		wantQuestions bool
+		wantOptions   []string
+	}{
+		{"no shuffle", QuizSettings{}, false, nil},
+		{"questions only", QuizSettings{ShuffleQuestions: true}, true, nil},
+		{"options only", QuizSettings{ShuffleOptions: true}, false, []string{"q1", "q3"}},
+		{"both", QuizSettings{ShuffleQuestions: true, ShuffleOptions: true}, true, []string{"q1", "q3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{ID: "u1"}
+			user.AssignShuffle(questions, tt.settings)
+
+			if tt.wantQuestions {
+				got := append([]string(nil), user.QuestionOrder...)
+				sort.Strings(got)
+				if !reflect.DeepEqual(got, []string{"q1", "q2", "q3"}) {
+					t.Errorf("question order %v is not a permutation of the questions", user.QuestionOrder)
+				}
+			} else if user.QuestionOrder != nil {
+				t.Errorf("question order = %v, want none", user.QuestionOrder)
+			}
+
+			var shuffled []string
+			for questionID, order := range user.OptionOrders {
+				shuffled = append(shuffled, questionID)
+				for i, index := range sortedCopy(order) {
+					if i != index {
+						t.Errorf("option order %v for %s is not a permutation", order, questionID)
+						break
+					}
+				}
+			}
+			sort.Strings(shuffled)
+			if !reflect.DeepEqual(shuffled, tt.wantOptions) {
+				t.Errorf("shuffled options of %v, want %v", shuffled, tt.wantOptions)
+			}
+		})
+	}
+}
+
+func sortedCopy(values []int) []int {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	return sorted
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TextMatchOptions controls how free-text answers are compared with accepted answers
+type TextMatchOptions struct {
+	CaseSensitive    bool `json:"case_sensitive"`
+	KeepDiacritics   bool `json:"keep_diacritics"`
+	KeepPunctuation  bool `json:"keep_punctuation"`
+	IgnoreWhitespace bool `json:"ignore_whitespace"`
+	MaxEditDistance  int  `json:"max_edit_distance"`
+}
+
+// NormalizeText applies the configured normalization to a free-text answer.
+// Whitespace is always trimmed and collapsed, or removed when IgnoreWhitespace is set.
+func (o TextMatchOptions) NormalizeText(text string) string {
+	if !o.CaseSensitive {
+		text = strings.ToLower(text)
+	}
+	if !o.KeepDiacritics {
+		text = StripDiacritics(text)
+	}
+
+	var b strings.Builder
+	pendingSpace := false
+	for _, r := range text {
+		if !o.KeepPunctuation && (unicode.IsPunct(r) || unicode.IsSymbol(r)) {
+			continue
+		}
+		if unicode.IsSpace(r) {
+			pendingSpace = b.Len() > 0
+			continue
+		}
+		if pendingSpace && !o.IgnoreWhitespace {
+			b.WriteRune(' ')
+		}
+		pendingSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Matches reports whether a submitted text matches any accepted answer
+func (o TextMatchOptions) Matches(text string, accepted []string) bool {
+	normalized := o.NormalizeText(text)
+	for _, candidate := range accepted {
+		expected := o.NormalizeText(candidate)
+		if normalized == expected {
+			return true
+		}
+		if o.MaxEditDistance > 0 && editDistance(normalized, expected) <= o.MaxEditDistance {
+			return true
+		}
+	}
+	return false
+}
+
+// StripDiacritics removes combining marks (Vietnamese tone and vowel marks included) and maps đ to d
+func StripDiacritics(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r == 'đ':
+			b.WriteRune('d')
+		case r == 'Đ':
+			b.WriteRune('D')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return norm.NFC.String(b.String())
+}
+
+// editDistance computes the Levenshtein distance between two strings, rune by rune
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,82 @@
+package models
+
+import "testing"
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name    string
+		options TextMatchOptions
+		text    string
+		want    string
+	}{
+		{"defaults", TextMatchOptions{}, "  Hello,   World!  ", "hello world"},
+		{"vietnamese diacritics", TextMatchOptions{}, "Hà Nội", "ha noi"},
+		{"d with stroke", TextMatchOptions{}, "Đà Nẵng", "da nang"},
+		{"french accents", TextMatchOptions{}, "Crème Brûlée", "creme brulee"},
+		{"case sensitive", TextMatchOptions{CaseSensitive: true}, "Paris", "Paris"},
+		{"keep diacritics", TextMatchOptions{KeepDiacritics: true}, "Hà Nội", "hà nội"},
+		{"keep punctuation", TextMatchOptions{KeepPunctuation: true}, "e.g. this", "e.g. this"},
+		{"ignore whitespace", TextMatchOptions{IgnoreWhitespace: true}, "New  York City", "newyorkcity"},
+		{"symbols dropped", TextMatchOptions{}, "$100 + tax", "100 tax"},
+		{"only whitespace", TextMatchOptions{}, " \t\n ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.NormalizeText(tt.text); got != tt.want {
+				t.Errorf("NormalizeText(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  TextMatchOptions
+		text     string
+		accepted []string
+		want     bool
+	}{
+		{"exact", TextMatchOptions{}, "Paris", []string{"Paris"}, true},
+		{"normalized", TextMatchOptions{}, "  paris! ", []string{"Paris"}, true},
+		{"any variant", TextMatchOptions{}, "NYC", []string{"New York", "NYC"}, true},
+		{"diacritics ignored", TextMatchOptions{}, "ha noi", []string{"Hà Nội"}, true},
+		{"diacritics kept", TextMatchOptions{KeepDiacritics: true}, "ha noi", []string{"Hà Nội"}, false},
+		{"case sensitive", TextMatchOptions{CaseSensitive: true}, "paris", []string{"Paris"}, false},
+		{"typo without tolerance", TextMatchOptions{}, "Pariss", []string{"Paris"}, false},
+		{"typo within tolerance", TextMatchOptions{MaxEditDistance: 1}, "Pariss", []string{"Paris"}, true},
+		{"swapped letters", TextMatchOptions{MaxEditDistance: 2}, "Prais", []string{"Paris"}, true},
+		{"too many typos", TextMatchOptions{MaxEditDistance: 1}, "Porus", []string{"Paris"}, false},
+		{"typo after normalization", TextMatchOptions{MaxEditDistance: 1}, "Ha Noj", []string{"Hà Nội"}, true},
+		{"no accepted answers", TextMatchOptions{}, "Paris", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.options.Matches(tt.text, tt.accepted); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.text, tt.accepted, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"paris", "paris", 0},
+		{"hà", "ha", 1}, // Counted in runes, not bytes
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
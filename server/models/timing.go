@@ -0,0 +1,97 @@
+package models
+
+import "time"
+
+// GetTimeLimit returns the question's time limit in seconds, falling back to the quiz default (0 = untimed)
+func (q *Question) GetTimeLimit(settings QuizSettings) int {
+	if q.TimeLimit > 0 {
+		return q.TimeLimit
+	}
+	return settings.TimeLimit
+}
+
+// OpenCurrentQuestion records when the current question opened and sets its deadline.
+// Closed questions shown again for review get neither. It returns the deadline, if any.
+func (q *Quiz) OpenCurrentQuestion(now time.Time) *time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.QuestionOpenedAt, q.QuestionDeadline = nil, nil
+
+	question := q.currentQuestion()
+	if question == nil || q.ClosedQuestions[question.ID] {
+		return nil
+	}
+
+	q.QuestionOpenedAt = &now
+	if limit := q.timeLimitFor(question); limit > 0 {
+		deadline := now.Add(time.Duration(limit) * time.Second)
+		q.QuestionDeadline = &deadline
+	}
+	return q.QuestionDeadline
+}
+
+// GetQuestionDeadline returns the current question's deadline, or nil when it is untimed
+func (q *Quiz) GetQuestionDeadline() *time.Time {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.QuestionDeadline
+}
+
+// IsPastDeadline reports whether t is later than the current question's deadline plus grace
+func (q *Quiz) IsPastDeadline(t time.Time, grace time.Duration) bool {
+	deadline := q.GetQuestionDeadline()
+	return deadline != nil && t.After(deadline.Add(grace))
+}
+
+// Pause records when a running quiz was paused; the current question keeps its remaining time until Resume
+func (q *Quiz) Pause(now time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.PausedAt = &now
+}
+
+// Resume pushes the current question's open time and deadline back by the length of the pause,
+// and in self-paced quizzes the deadlines of running attempts. It returns the new deadline, if any.
+func (q *Quiz) Resume(now time.Time) *time.Time {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.PausedAt != nil {
+		paused := now.Sub(*q.PausedAt)
+		if q.QuestionOpenedAt != nil {
+			openedAt := q.QuestionOpenedAt.Add(paused)
+			q.QuestionOpenedAt = &openedAt
+		}
+		if q.QuestionDeadline != nil {
+			deadline := q.QuestionDeadline.Add(paused)
+			q.QuestionDeadline = &deadline
+		}
+		if q.Settings.IsSelfPaced() {
+			for _, user := range q.Participants {
+				user.shiftAttempt(*q.PausedAt, paused, q.Settings.ClosesAt)
+			}
+		}
+	}
+
+	q.PausedAt = nil
+	return q.QuestionDeadline
+}
+
+// RemainingTime returns how much time the current question has left, measured at the pause
+// while the quiz is paused. It returns false when the question is untimed.
+func (q *Quiz) RemainingTime(now time.Time) (time.Duration, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.QuestionDeadline == nil {
+		return 0, false
+	}
+	if q.PausedAt != nil {
+		now = *q.PausedAt
+	}
+	if remaining := q.QuestionDeadline.Sub(now); remaining > 0 {
+		return remaining, true
+	}
+	return 0, true
+}
@@ -0,0 +1,193 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetTimeLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		question Question
+		settings QuizSettings
+		want     int
+	}{
+		{"untimed", Question{}, QuizSettings{}, 0},
+		{"quiz default", Question{}, QuizSettings{TimeLimit: 20}, 20},
+		{"question overrides default", Question{TimeLimit: 5}, QuizSettings{TimeLimit: 20}, 5},
+		{"question limit without default", Question{TimeLimit: 5}, QuizSettings{}, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.question.GetTimeLimit(tt.settings); got != tt.want {
+				t.Errorf("GetTimeLimit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenCurrentQuestion(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		status       QuizStatus
+		settings     QuizSettings
+		timeLimit    int
+		closed       bool
+		wantOpened   bool
+		wantDeadline time.Duration
+	}{
+		{"timed question", QuizStatusActive, QuizSettings{}, 10, false, true, 10 * time.Second},
+		{"quiz default", QuizStatusActive, QuizSettings{TimeLimit: 30}, 0, false, true, 30 * time.Second},
+		{"untimed", QuizStatusActive, QuizSettings{}, 0, false, true, 0},
+		{"closed question shown again", QuizStatusActive, QuizSettings{TimeLimit: 30}, 0, true, false, 0},
+		{"quiz not running", QuizStatusWaiting, QuizSettings{TimeLimit: 30}, 0, false, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{
+				Status:    tt.status,
+				Settings:  tt.settings,
+				Questions: []Question{{ID: "q1", TimeLimit: tt.timeLimit}},
+			}
+			if tt.closed {
+				quiz.ClosedQuestions = map[string]bool{"q1": true}
+			}
+
+			deadline := quiz.OpenCurrentQuestion(now)
+
+			if opened := quiz.QuestionOpenedAt != nil; opened != tt.wantOpened {
+				t.Errorf("opened = %v, want %v", opened, tt.wantOpened)
+			}
+			if tt.wantDeadline == 0 {
+				if deadline != nil {
+					t.Errorf("deadline = %v, want none", deadline)
+				}
+				return
+			}
+			if deadline == nil || !deadline.Equal(now.Add(tt.wantDeadline)) {
+				t.Errorf("deadline = %v, want %v", deadline, now.Add(tt.wantDeadline))
+			}
+		})
+	}
+}
+
+func TestIsPastDeadline(t *testing.T) {
+	deadline := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	grace := 2 * time.Second
+
+	tests := []struct {
+		name     string
+		deadline *time.Time
+		at       time.Time
+		want     bool
+	}{
+		{"untimed", nil, deadline.Add(time.Hour), false},
+		{"before deadline", &deadline, deadline.Add(-time.Second), false},
+		{"within grace", &deadline, deadline.Add(grace), false},
+		{"past grace", &deadline, deadline.Add(grace + time.Millisecond), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{QuestionDeadline: tt.deadline}
+			if got := quiz.IsPastDeadline(tt.at, grace); got != tt.want {
+				t.Errorf("IsPastDeadline(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResume(t *testing.T) {
+	openedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	deadline := openedAt.Add(30 * time.Second)
+	pausedAt := openedAt.Add(10 * time.Second)
+
+	tests := []struct {
+		name         string
+		deadline     *time.Time
+		paused       bool
+		resumeAfter  time.Duration
+		wantOpenedAt time.Time
+		wantDeadline *time.Time
+	}{
+		{
+			name:         "timed question keeps its remaining time",
+			deadline:     &deadline,
+			paused:       true,
+			resumeAfter:  time.Minute,
+			wantOpenedAt: openedAt.Add(time.Minute),
+			wantDeadline: timePtr(deadline.Add(time.Minute)),
+		},
+		{
+			name:         "untimed question",
+			paused:       true,
+			resumeAfter:  time.Minute,
+			wantOpenedAt: openedAt.Add(time.Minute),
+		},
+		{
+			name:         "not paused",
+			deadline:     &deadline,
+			resumeAfter:  time.Minute,
+			wantOpenedAt: openedAt,
+			wantDeadline: &deadline,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opened := openedAt
+			quiz := &Quiz{QuestionOpenedAt: &opened, QuestionDeadline: tt.deadline}
+			if tt.paused {
+				quiz.Pause(pausedAt)
+			}
+
+			got := quiz.Resume(pausedAt.Add(tt.resumeAfter))
+
+			if quiz.PausedAt != nil {
+				t.Errorf("PausedAt = %v, want cleared", quiz.PausedAt)
+			}
+			if !quiz.QuestionOpenedAt.Equal(tt.wantOpenedAt) {
+				t.Errorf("opened at = %v, want %v", quiz.QuestionOpenedAt, tt.wantOpenedAt)
+			}
+			if (got == nil) != (tt.wantDeadline == nil) || (got != nil && !got.Equal(*tt.wantDeadline)) {
+				t.Errorf("deadline = %v, want %v", got, tt.wantDeadline)
+			}
+		})
+	}
+}
+
+func TestRemainingTime(t *testing.T) {
+	deadline := time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		deadline  *time.Time
+		pausedAt  *time.Time
+		now       time.Time
+		want      time.Duration
+		wantTimed bool
+	}{
+		{"untimed", nil, nil, deadline, 0, false},
+		{"running", &deadline, nil, deadline.Add(-20 * time.Second), 20 * time.Second, true},
+		{"time is up", &deadline, nil, deadline.Add(time.Second), 0, true},
+		{"paused", &deadline, timePtr(deadline.Add(-15 * time.Second)), deadline.Add(time.Hour), 15 * time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quiz := &Quiz{QuestionDeadline: tt.deadline, PausedAt: tt.pausedAt}
+			got, timed := quiz.RemainingTime(tt.now)
+			if got != tt.want || timed != tt.wantTimed {
+				t.Errorf("RemainingTime() = %v, %v, want %v, %v", got, timed, tt.want, tt.wantTimed)
+			}
+		})
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
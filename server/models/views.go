@@ -0,0 +1,152 @@
+package models
+
+import "time"
+
+// ParticipantQuiz is the participant-facing view of a quiz: no correctness data,
+// no host token, no other participants' answers and no questions the host has not reached yet
+type ParticipantQuiz struct {
+	ID               string                `json:"id"`
+	Title            string                `json:"title"`
+	Questions        []ParticipantQuestion `json:"questions"`
+	CurrentQuestion  *ParticipantQuestion  `json:"current_question,omitempty"`
+	QuestionIndex    int                   `json:"question_index"`
+	QuestionCount    int                   `json:"question_count"`
+	QuestionOpenedAt *time.Time            `json:"question_opened_at,omitempty"`
+	QuestionDeadline *time.Time            `json:"question_deadline,omitempty"`
+	Round            *RoundSummary         `json:"round,omitempty"`
+	Intermission     bool                  `json:"intermission,omitempty"`
+	Participants     []ParticipantSummary  `json:"participants"`
+	Status           QuizStatus            `json:"status"`
+	Locked           bool                  `json:"locked"`
+	CreatedAt        time.Time             `json:"created_at"`
+	ScheduledStart   *time.Time            `json:"scheduled_start,omitempty"`
+	StartedAt        *time.Time            `json:"started_at,omitempty"`
+	EndedAt          *time.Time            `json:"ended_at,omitempty"`
+}
+
+// ParticipantQuestion is a question without its correct answer
+type ParticipantQuestion struct {
+	ID       string       `json:"id"`
+	Type     QuestionType `json:"type"`
+	Text     string       `json:"text"`
+	Options  []string     `json:"options,omitempty"`
+	Points   int          `json:"points"`
+	Category string       `json:"category"`
+}
+
+// ParticipantSummary is the public part of a participant
+type ParticipantSummary struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+// ParticipantView returns the question as participants may see it, with options in the given
+// order (canonical order when optionOrder is nil)
+func (q *Question) ParticipantView(optionOrder []int) ParticipantQuestion {
+	options := q.Options
+	if optionOrder != nil {
+		options = make([]string, len(optionOrder))
+		for i, index := range optionOrder {
+			options[i] = q.Options[index]
+		}
+	}
+
+	return ParticipantQuestion{
+		ID:       q.ID,
+		Type:     q.GetType(),
+		Text:     q.Text,
+		Options:  options,
+		Points:   q.Points,
+		Category: q.Category,
+	}
+}
+
+// ParticipantView returns the quiz as participants may see it while it is running: the questions
+// shown so far and the current one. When user is set, options follow that participant's shuffled order.
+func (q *Quiz) ParticipantView(user *User) *ParticipantQuiz {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	view := &ParticipantQuiz{
+		ID:               q.ID,
+		Title:            q.Title,
+		Questions:        make([]ParticipantQuestion, 0, len(q.Questions)),
+		QuestionIndex:    q.CurrentQuestion,
+		QuestionCount:    len(q.Questions),
+		QuestionOpenedAt: q.QuestionOpenedAt,
+		QuestionDeadline: q.QuestionDeadline,
+		Participants:     make([]ParticipantSummary, 0, len(q.Participants)),
+		Status:           q.Status,
+		Locked:           q.Locked,
+		CreatedAt:        q.CreatedAt,
+		ScheduledStart:   q.ScheduledStart,
+		StartedAt:        q.StartedAt,
+		EndedAt:          q.EndedAt,
+	}
+
+	if q.Settings.IsSelfPaced() {
+		view.QuestionIndex, view.QuestionOpenedAt, view.QuestionDeadline = 0, nil, nil
+		if user != nil && q.Status == QuizStatusActive {
+			view.Questions, view.CurrentQuestion, view.QuestionIndex = user.attemptViews(q.Questions)
+		}
+	} else if current := q.currentQuestion(); current != nil {
+		for i := 0; i <= q.CurrentQuestion; i++ {
+			var optionOrder []int
+			if user != nil {
+				optionOrder = user.OptionOrder(&q.Questions[i])
+			}
+			view.Questions = append(view.Questions, q.Questions[i].ParticipantView(optionOrder))
+		}
+		view.CurrentQuestion = &view.Questions[q.CurrentQuestion]
+		view.Round = q.roundSummary(q.roundIndexOf(current.ID))
+		view.Intermission = q.Intermission
+	}
+	for _, user := range q.Participants {
+		view.Participants = append(view.Participants, ParticipantSummary{
+			ID:    user.ID,
+			Name:  user.Name,
+			Score: user.GetScore(),
+		})
+	}
+
+	return view
+}
+
+// QuizReview is the full quiz with the host token hidden, used for post-quiz review.
+// The outer HostToken shadows the embedded one when encoding.
+type QuizReview struct {
+	*Quiz
+	HostToken string `json:"host_token,omitempty"`
+}
+
+// ViewFor returns the full quiz for hosts, the review for ended quizzes and the participant view
+// (personalized for user, if given) otherwise
+func (q *Quiz) ViewFor(isHost bool, user *User) interface{} {
+	if isHost {
+		return q
+	}
+	if q.GetStatus() == QuizStatusEnded {
+		return QuizReview{Quiz: q}
+	}
+	return q.ParticipantView(user)
+}
+
+// attemptViews returns the questions a self-paced user has reached, their current question and cursor
+func (u *User) attemptViews(questions []Question) ([]ParticipantQuestion, *ParticipantQuestion, int) {
+	current := u.AttemptQuestion(questions)
+	ordered := u.OrderedQuestions(questions)
+
+	u.mu.RLock()
+	cursor := u.Cursor
+	u.mu.RUnlock()
+
+	views := make([]ParticipantQuestion, 0, cursor+1)
+	for i := 0; i < len(ordered) && i <= cursor; i++ {
+		views = append(views, ordered[i].ParticipantView(u.OptionOrder(ordered[i])))
+	}
+	if current == nil || cursor >= len(views) {
+		return views, nil, cursor
+	}
+	return views, &views[cursor], cursor
+}
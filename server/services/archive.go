@@ -0,0 +1,59 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "log"
+  "sort"
+)
+
+// ListArchivedQuizzes returns a page of ended quizzes, most recently ended first, and the total count
+func (qs *QuizService) ListArchivedQuizzes(offset, limit int) ([]*models.Quiz, int, error) {
+  if qs.RedisService.IsAvailable() {
+    quizIDs, total, err := qs.RedisService.GetArchivedQuizIDs(offset, limit)
+    if err != nil {
+      return nil, 0, err
+    }
+
+    quizzes := make([]*models.Quiz, 0, len(quizIDs))
+    for _, quizID := range quizIDs {
+      // Read archived quizzes straight from Redis rather than pulling them all into memory
+      quiz, exists := qs.cachedQuiz(quizID)
+      if !exists {
+        quiz, err = qs.RedisService.GetQuiz(quizID)
+        if err != nil {
+          log.Printf("Warning: failed to load archived quiz %s: %v", quizID, err)
+          continue
+        }
+      }
+      quizzes = append(quizzes, quiz)
+    }
+    return quizzes, int(total), nil
+  }
+
+  ended := make([]*models.Quiz, 0)
+  for _, quiz := range qs.cachedQuizzes() {
+    if quiz.GetStatus() == models.QuizStatusEnded {
+      ended = append(ended, quiz)
+    }
+  }
+
+  sort.Slice(ended, func(i, j int) bool {
+    return endedAt(ended[i]) > endedAt(ended[j])
+  })
+
+  if offset >= len(ended) {
+    return []*models.Quiz{}, len(ended), nil
+  }
+  end := offset + limit
+  if end > len(ended) {
+    end = len(ended)
+  }
+  return ended[offset:end], len(ended), nil
+}
+
+func endedAt(quiz *models.Quiz) int64 {
+  if quiz.EndedAt == nil {
+    return 0
+  }
+  return quiz.EndedAt.UnixNano()
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "encoding/json"
+  "fmt"
+  "log"
+  "strings"
+  "time"
+)
+
+// StartAttempt starts a participant's personal clock in a self-paced quiz and sends them the first question
+func (qs *QuizService) StartAttempt(quizID, userID string) (models.AttemptState, error) {
+  quiz, user, err := qs.selfPacedParticipant(quizID, userID)
+  if err != nil {
+    return models.AttemptState{}, err
+  }
+
+  if err := checkOperation(quiz, ActionAttempt); err != nil {
+    return models.AttemptState{}, err
+  }
+
+  now := time.Now()
+  if !quiz.Settings.IsOpenAt(now) {
+    return models.AttemptState{}, fmt.Errorf("quiz is not open for attempts")
+  }
+
+  if err := user.StartAttempt(now, quiz.Settings); err != nil {
+    return models.AttemptState{}, err
+  }
+  qs.armAttemptTimer(quiz, user)
+
+  err = qs.RedisService.SaveUser(user)
+  if err != nil {
+    log.Printf("Warning: failed to save user to Redis: %v", err)
+  }
+
+  state := quiz.AttemptState(user)
+  qs.sendToUser(quizID, userID, models.WebSocketMessage{
+    Type:    "attempt_started",
+    Payload: state,
+  })
+
+  log.Printf("⏱️  User %s started an attempt at quiz %s", user.Name, quizID)
+  return state, nil
+}
+
+// FinishAttempt ends a participant's attempt early; their score counts from now on and
+// unanswered questions are penalized if negative marking counts skips
+func (qs *QuizService) FinishAttempt(quizID, userID string) (models.AttemptState, error) {
+  quiz, user, err := qs.selfPacedParticipant(quizID, userID)
+  if err != nil {
+    return models.AttemptState{}, err
+  }
+
+  if err := user.FinishAttempt(time.Now()); err != nil {
+    return models.AttemptState{}, err
+  }
+
+  qs.penalizeAttemptSkips(quiz, user)
+  qs.attemptFinished(quiz, user)
+  return quiz.AttemptState(user), nil
+}
+
+// GetAttempt returns a participant's progress through a self-paced quiz
+func (qs *QuizService) GetAttempt(quizID, userID string) (models.AttemptState, error) {
+  quiz, user, err := qs.selfPacedParticipant(quizID, userID)
+  if err != nil {
+    return models.AttemptState{}, err
+  }
+
+  qs.settleAttempt(quiz, user)
+  return quiz.AttemptState(user), nil
+}
+
+func (qs *QuizService) selfPacedParticipant(quizID, userID string) (*models.Quiz, *models.User, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, nil, err
+  }
+
+  if !quiz.Settings.IsSelfPaced() {
+    return nil, nil, fmt.Errorf("quiz is not self-paced: %s", quizID)
+  }
+
+  user, exists := quiz.Participants[userID]
+  if !exists {
+    return nil, nil, fmt.Errorf("user not found: %s", userID)
+  }
+  return quiz, user, nil
+}
+
+// checkAttemptAnswer enforces the participant's own clock: only the question at their cursor
+// is open, and only until their deadline (plus grace) inside the quiz window
+func checkAttemptAnswer(quiz *models.Quiz, user *models.User, questionID string, answeredAt time.Time) error {
+  question := user.AttemptQuestion(quiz.Questions)
+  if question == nil {
+    if user.AttemptStartedAt == nil {
+      return fmt.Errorf("attempt not started")
+    }
+    return fmt.Errorf("attempt already finished")
+  }
+
+  if question.ID != questionID {
+    return fmt.Errorf("question is not open: %s", questionID)
+  }
+
+  if user.IsAttemptExpired(answeredAt, answerGracePeriod) {
+    return fmt.Errorf("time is up for this attempt")
+  }
+  return nil
+}
+
+// advanceAttempt moves the participant to their next question after an answer
+func (qs *QuizService) advanceAttempt(quiz *models.Quiz, user *models.User) {
+  user.AdvanceAttempt(time.Now(), len(quiz.Questions))
+
+  err := qs.RedisService.SaveUser(user)
+  if err != nil {
+    log.Printf("Warning: failed to save user to Redis: %v", err)
+  }
+
+  if user.AttemptComplete(time.Now()) {
+    qs.attemptFinished(quiz, user)
+    return
+  }
+
+  qs.sendToUser(quiz.ID, user.ID, models.WebSocketMessage{
+    Type:    "attempt_question",
+    Payload: quiz.AttemptState(user),
+  })
+}
+
+// attemptFinished saves a completed attempt and publishes the leaderboard it now appears on
+func (qs *QuizService) attemptFinished(quiz *models.Quiz, user *models.User) {
+  qs.stopAttemptTimer(quiz.ID, user.ID)
+
+  err := qs.RedisService.SaveUser(user)
+  if err != nil {
+    log.Printf("Warning: failed to save user to Redis: %v", err)
+  }
+
+  qs.sendToUser(quiz.ID, user.ID, models.WebSocketMessage{
+    Type:    "attempt_finished",
+    Payload: quiz.AttemptState(user),
+  })
+  qs.broadcastLeaderboard(quiz.ID)
+
+  log.Printf("🏁 User %s finished their attempt at quiz %s", user.Name, quiz.ID)
+}
+
+// penalizeAttemptSkips counts the questions an ended attempt left unanswered as skipped
+func (qs *QuizService) penalizeAttemptSkips(quiz *models.Quiz, user *models.User) {
+  for i := range quiz.Questions {
+    qs.penalizeSkip(quiz, &quiz.Questions[i], user)
+  }
+}
+
+// settleAttempt ends an attempt whose time ran out, penalizing its unanswered questions just as
+// finishing it would. Paused quizzes are left alone: resuming gives attempts their time back.
+// Ended quizzes are too: ending one finishes every attempt. It returns whether the attempt was ended.
+func (qs *QuizService) settleAttempt(quiz *models.Quiz, user *models.User) bool {
+  switch quiz.GetStatus() {
+  case models.QuizStatusPaused, models.QuizStatusEnded:
+    return false
+  }
+  if !user.ExpireAttempt(time.Now(), answerGracePeriod) {
+    return false
+  }
+
+  qs.penalizeAttemptSkips(quiz, user)
+  qs.attemptFinished(quiz, user)
+  return true
+}
+
+// armAttemptTimer settles the user's attempt once its deadline (plus grace) has passed
+func (qs *QuizService) armAttemptTimer(quiz *models.Quiz, user *models.User) {
+  qs.stopAttemptTimer(quiz.ID, user.ID)
+
+  deadline := quiz.AttemptState(user).Deadline
+  if deadline == nil {
+    return
+  }
+
+  quizID, userID := quiz.ID, user.ID
+  timer := time.AfterFunc(time.Until(deadline.Add(answerGracePeriod)), func() {
+    quiz, err := qs.GetQuiz(quizID)
+    if err != nil {
+      return
+    }
+    if user, exists := quiz.GetParticipants()[userID]; exists {
+      qs.settleAttempt(quiz, user)
+    }
+  })
+
+  qs.timersMu.Lock()
+  qs.attemptTimers[quizID+":"+userID] = timer
+  qs.timersMu.Unlock()
+}
+
+// armAttemptTimers re-arms the timers of a self-paced quiz's running attempts, e.g. after loading it
+func (qs *QuizService) armAttemptTimers(quiz *models.Quiz) {
+  if !quiz.Settings.IsSelfPaced() {
+    return
+  }
+  for _, user := range quiz.GetParticipants() {
+    if user.AttemptStartedAt != nil && user.AttemptFinishedAt == nil {
+      qs.armAttemptTimer(quiz, user)
+    }
+  }
+}
+
+// finishRunningAttempts ends the attempts still running when a self-paced quiz ends, penalizing
+// their unanswered questions, so that everyone who started one is on the final leaderboard.
+// Attempts out of time finish at their deadline, the others now.
+func (qs *QuizService) finishRunningAttempts(quiz *models.Quiz, now time.Time) {
+  for userID, user := range quiz.GetParticipants() {
+    if !user.ExpireAttempt(now, 0) && user.FinishAttempt(now) != nil {
+      continue
+    }
+    qs.penalizeAttemptSkips(quiz, user)
+
+    err := qs.RedisService.SaveUser(user)
+    if err != nil {
+      log.Printf("Warning: failed to save user to Redis: %v", err)
+    }
+
+    qs.sendToUser(quiz.ID, userID, models.WebSocketMessage{
+      Type:    "attempt_finished",
+      Payload: quiz.AttemptState(user),
+    })
+  }
+  qs.stopAttemptTimers(quiz.ID)
+}
+
+// stopAttemptTimer cancels the pending timer of a user's attempt, if any
+func (qs *QuizService) stopAttemptTimer(quizID, userID string) {
+  qs.timersMu.Lock()
+  defer qs.timersMu.Unlock()
+  key := quizID + ":" + userID
+  if timer, ok := qs.attemptTimers[key]; ok {
+    timer.Stop()
+    delete(qs.attemptTimers, key)
+  }
+}
+
+// stopAttemptTimers cancels the pending timers of every attempt at a quiz
+func (qs *QuizService) stopAttemptTimers(quizID string) {
+  qs.timersMu.Lock()
+  defer qs.timersMu.Unlock()
+  prefix := quizID + ":"
+  for key, timer := range qs.attemptTimers {
+    if strings.HasPrefix(key, prefix) {
+      timer.Stop()
+      delete(qs.attemptTimers, key)
+    }
+  }
+}
+
+// sendToUser delivers a message to one participant's local connections
+func (qs *QuizService) sendToUser(quizID, userID string, message models.WebSocketMessage) {
+  data, err := json.Marshal(message)
+  if err != nil {
+    log.Printf("Error marshaling message: %v", err)
+    return
+  }
+
+  qs.Mu.RLock()
+  defer qs.Mu.RUnlock()
+  for client := range qs.Clients {
+    if client.QuizID != quizID || client.UserID != userID {
+      continue
+    }
+
+    select {
+    case client.Send <- data:
+    default:
+      // Dead clients are removed by the next broadcastToQuiz
+    }
+  }
+}
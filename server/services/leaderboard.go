@@ -0,0 +1,157 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+
+  "github.com/redis/go-redis/v9"
+)
+
+// leaderboardInRedis reports whether the quiz's leaderboard is kept in a Redis sorted set.
+// Self-paced quizzes rank completed attempts only, which depends on each attempt's deadline,
+// so their leaderboards are always ranked in memory.
+func (qs *QuizService) leaderboardInRedis(quiz *models.Quiz) bool {
+  return qs.RedisService.IsAvailable() && !quiz.Settings.IsSelfPaced()
+}
+
+// recordScore adds the points a user just gained or lost to the quiz's Redis leaderboard
+func (qs *QuizService) recordScore(quiz *models.Quiz, user *models.User, points int) {
+  if points == 0 || !qs.leaderboardInRedis(quiz) {
+    return
+  }
+
+  err := qs.RedisService.IncrLeaderboardScore(quiz.ID, user.ID, points)
+  if err != nil {
+    log.Printf("Warning: %v", err)
+  }
+}
+
+// GetLeaderboardPage returns a page of the leaderboard, highest score first, and the number
+// of users on it
+func (qs *QuizService) GetLeaderboardPage(quizID string, offset, limit int) ([]models.LeaderboardEntry, int, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, 0, err
+  }
+
+  if qs.leaderboardInRedis(quiz) {
+    return qs.leaderboardRange(quiz, offset, offset+limit-1)
+  }
+
+  leaderboard := quiz.GetLeaderboard()
+  return pageOf(leaderboard, offset, offset+limit), len(leaderboard), nil
+}
+
+// GetLeaderboardAround returns the user's leaderboard entry with up to radius entries either
+// side of it, and the number of users on the leaderboard
+func (qs *QuizService) GetLeaderboardAround(quizID, userID string, radius int) ([]models.LeaderboardEntry, int, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, 0, err
+  }
+
+  if qs.leaderboardInRedis(quiz) {
+    rank, exists, err := qs.RedisService.GetLeaderboardRank(quizID, userID)
+    if err != nil {
+      return nil, 0, err
+    }
+    if !exists {
+      return nil, 0, fmt.Errorf("user not on leaderboard: %s", userID)
+    }
+
+    // The Redis rank orders equal scores by user ID; find the user's place among them
+    block, ahead, _, err := qs.rankedBlock(quiz, rank, rank)
+    if err != nil {
+      return nil, 0, err
+    }
+    index := int(rank)
+    for i, entry := range block {
+      if entry.UserID == userID {
+        index = ahead + i
+        break
+      }
+    }
+    return qs.leaderboardRange(quiz, max(index-radius, 0), index+radius)
+  }
+
+  leaderboard := quiz.GetLeaderboard()
+  for i, entry := range leaderboard {
+    if entry.UserID == userID {
+      return pageOf(leaderboard, max(i-radius, 0), i+radius+1), len(leaderboard), nil
+    }
+  }
+  return nil, 0, fmt.Errorf("user not on leaderboard: %s", userID)
+}
+
+// leaderboardRange returns the leaderboard entries from start to stop read from Redis, ordered
+// with the same tie-breakers as the in-memory leaderboard
+func (qs *QuizService) leaderboardRange(quiz *models.Quiz, start, stop int) ([]models.LeaderboardEntry, int, error) {
+  block, ahead, total, err := qs.rankedBlock(quiz, int64(start), int64(stop))
+  if err != nil {
+    return nil, 0, err
+  }
+  return blockPage(block, ahead, start, stop), total, nil
+}
+
+// rankedBlock reads the entries from start to stop from Redis along with everyone tied with
+// them, and ranks that block with the participants' tie-breakers. It returns the block, the
+// number of entries ahead of it and the number of users on the leaderboard.
+func (qs *QuizService) rankedBlock(quiz *models.Quiz, start, stop int64) ([]models.LeaderboardEntry, int, int, error) {
+  scores, total, higher, err := qs.RedisService.GetLeaderboardBlock(quiz.ID, start, stop)
+  if err != nil {
+    return nil, 0, 0, err
+  }
+  return rankScores(quiz, scores, int(higher)), int(higher), int(total), nil
+}
+
+// rankScores ranks a block of Redis leaderboard scores with the participants' tie-breakers,
+// numbering positions after the ahead entries with higher scores
+func rankScores(quiz *models.Quiz, scores []redis.Z, ahead int) []models.LeaderboardEntry {
+  entries := make([]models.LeaderboardEntry, 0, len(scores))
+  for _, score := range scores {
+    userID, _ := score.Member.(string)
+    entry, exists := quiz.LeaderboardEntryFor(userID)
+    if !exists {
+      entry = models.LeaderboardEntry{UserID: userID}
+    }
+    entry.Score = int(score.Score)
+    entries = append(entries, entry)
+  }
+  return models.RankBlock(entries, ahead)
+}
+
+// blockPage returns the entries from start to stop of the whole leaderboard out of a ranked
+// block that begins after ahead entries
+func blockPage(block []models.LeaderboardEntry, ahead, start, stop int) []models.LeaderboardEntry {
+  return pageOf(block, max(start-ahead, 0), stop-ahead+1)
+}
+
+// migrateLeaderboard makes sure a quiz loaded from Redis has a leaderboard sorted set,
+// seeding it from the participants' scores
+func (qs *QuizService) migrateLeaderboard(quiz *models.Quiz) {
+  if !qs.leaderboardInRedis(quiz) {
+    return
+  }
+
+  scores := make(map[string]int)
+  for userID, user := range quiz.GetParticipants() {
+    scores[userID] = user.GetScore()
+  }
+
+  err := qs.RedisService.MigrateLeaderboard(quiz.ID, scores)
+  if err != nil {
+    log.Printf("Warning: %v", err)
+  }
+}
+
+// pageOf returns leaderboard[start:end], clamped to the leaderboard
+func pageOf(leaderboard []models.LeaderboardEntry, start, end int) []models.LeaderboardEntry {
+  if end > len(leaderboard) {
+    end = len(leaderboard)
+  }
+  if start >= end {
+    return []models.LeaderboardEntry{}
+  }
+  return leaderboard[start:end]
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "reflect"
+  "sort"
+  "testing"
+  "time"
+
+  "github.com/redis/go-redis/v9"
+)
+
+// redisBlock mimics RedisService.GetLeaderboardBlock over an in-memory sorted set: the entries
+// from start to stop, widened to everyone tied with them, and the number with higher scores
+func redisBlock(scores map[string]int, start, stop int) ([]redis.Z, int) {
+  all := make([]redis.Z, 0, len(scores))
+  for userID, score := range scores {
+    all = append(all, redis.Z{Member: userID, Score: float64(score)})
+  }
+  // ZREVRANGE lists equal scores in reverse member order
+  sort.Slice(all, func(i, j int) bool {
+    if all[i].Score != all[j].Score {
+      return all[i].Score > all[j].Score
+    }
+    return all[i].Member.(string) > all[j].Member.(string)
+  })
+
+  if stop >= len(all) {
+    stop = len(all) - 1
+  }
+  if start > stop {
+    return nil, 0
+  }
+  top, bottom := all[start].Score, all[stop].Score
+
+  block := make([]redis.Z, 0)
+  higher := 0
+  for _, z := range all {
+    if z.Score > top {
+      higher++
+    } else if z.Score >= bottom {
+      block = append(block, z)
+    }
+  }
+  return block, higher
+}
+
+func TestRedisLeaderboardPaging(t *testing.T) {
+  base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+  ms := func(value int64) *int64 { return &value }
+
+  // Ties at 50 and 20 are broken by correct-answer time, which disagrees with the member order
+  participants := []struct {
+    id            string
+    score         int
+    correctTimeMs int64
+  }{
+    {"a", 50, 9000},
+    {"b", 50, 3000},
+    {"c", 50, 6000},
+    {"d", 70, 1000},
+    {"e", 20, 2000},
+    {"f", 20, 1000},
+    {"g", 10, 1000},
+  }
+
+  quiz := &models.Quiz{ID: "quiz", Participants: make(map[string]*models.User)}
+  scores := make(map[string]int)
+  for i, p := range participants {
+    quiz.Participants[p.id] = &models.User{
+      ID:       p.id,
+      Score:    p.score,
+      JoinedAt: base,
+      Answers: []models.Answer{{
+        QuestionID: "q1",
+        Correct:    true,
+        ResponseMs: ms(p.correctTimeMs),
+        AnsweredAt: base.Add(time.Duration(i) * time.Second),
+      }},
+    }
+    scores[p.id] = p.score
+  }
+
+  want := quiz.GetLeaderboard()
+  wantOrder := make([]string, len(want))
+  wantPositions := make([]int, len(want))
+  for i, entry := range want {
+    wantOrder[i], wantPositions[i] = entry.UserID, entry.Position
+  }
+
+  for _, size := range []int{1, 2, 3, 4, len(participants)} {
+    t.Run(fmt.Sprintf("pages of %d", size), func(t *testing.T) {
+      order := make([]string, 0, len(participants))
+      positions := make([]int, 0, len(participants))
+      for start := 0; start < len(participants); start += size {
+        stop := start + size - 1
+        block, ahead := redisBlock(scores, start, stop)
+        for _, entry := range blockPage(rankScores(quiz, block, ahead), ahead, start, stop) {
+          order = append(order, entry.UserID)
+          positions = append(positions, entry.Position)
+        }
+      }
+
+      if !reflect.DeepEqual(order, wantOrder) {
+        t.Errorf("pages of %d list %v, want %v", size, order, wantOrder)
+      }
+      if !reflect.DeepEqual(positions, wantPositions) {
+        t.Errorf("pages of %d number %v, want %v", size, positions, wantPositions)
+      }
+    })
+  }
+}
+
+func TestPageOf(t *testing.T) {
+  leaderboard := []models.LeaderboardEntry{{UserID: "a"}, {UserID: "b"}, {UserID: "c"}}
+
+  tests := []struct {
+    name       string
+    start, end int
+    want       []string
+  }{
+    {"first page", 0, 2, []string{"a", "b"}},
+    {"clamped to the end", 1, 10, []string{"b", "c"}},
+    {"past the end", 3, 5, []string{}},
+    {"empty range", 2, 2, []string{}},
+  }
+
+  for _, tt := range tests {
+    t.Run(tt.name, func(t *testing.T) {
+      got := []string{}
+      for _, entry := range pageOf(leaderboard, tt.start, tt.end) {
+        got = append(got, entry.UserID)
+      }
+      if !reflect.DeepEqual(got, tt.want) {
+        t.Errorf("pageOf(%d, %d) = %v, want %v", tt.start, tt.end, got, tt.want)
+      }
+    })
+  }
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+)
+
+// SetLobbyLocked locks or unlocks a quiz's lobby; while locked nobody can join
+func (qs *QuizService) SetLobbyLocked(quizID string, locked bool) error {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return err
+  }
+
+  if err := checkOperation(quiz, ActionLockLobby); err != nil {
+    return err
+  }
+
+  if !quiz.SetLocked(locked) {
+    if locked {
+      return fmt.Errorf("lobby is already locked")
+    }
+    return fmt.Errorf("lobby is already unlocked")
+  }
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  messageType := "lobby_unlocked"
+  if locked {
+    messageType = "lobby_locked"
+  }
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type: messageType,
+    Payload: map[string]interface{}{
+      "quiz_id": quizID,
+    },
+  })
+
+  log.Printf("🚪 Quiz %s %s", quizID, messageType)
+  return nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "log"
+  "time"
+)
+
+// PauseQuiz freezes a running quiz: answers are rejected and the question timer stops
+// with its remaining time kept for ResumeQuiz
+func (qs *QuizService) PauseQuiz(quizID string) error {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return err
+  }
+
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+
+  if err := transition(quiz, ActionPause); err != nil {
+    return err
+  }
+
+  now := time.Now()
+  qs.stopQuestionTimer(quizID)
+  quiz.Pause(now)
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  payload := map[string]interface{}{
+    "quiz_id":   quizID,
+    "paused_at": now,
+  }
+  if remaining, timed := quiz.RemainingTime(now); timed {
+    payload["remaining_ms"] = remaining.Milliseconds()
+  }
+
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type:    "quiz_paused",
+    Payload: payload,
+  })
+
+  log.Printf("⏸️  Quiz %s paused", quizID)
+  return nil
+}
+
+// ResumeQuiz restarts a paused quiz, moving the question deadline (or in self-paced quizzes,
+// running attempts' deadlines) back by the length of the pause
+func (qs *QuizService) ResumeQuiz(quizID string) error {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return err
+  }
+
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+
+  if err := transition(quiz, ActionResume); err != nil {
+    return err
+  }
+
+  now := time.Now()
+  deadline := quiz.Resume(now)
+  qs.armQuestionTimer(quiz)
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  if quiz.Settings.IsSelfPaced() {
+    qs.attemptsResumed(quiz, now)
+  }
+
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type: "quiz_resumed",
+    Payload: map[string]interface{}{
+      "quiz_id":    quizID,
+      "resumed_at": now,
+      "deadline":   deadline,
+    },
+  })
+
+  log.Printf("▶️  Quiz %s resumed", quizID)
+  return nil
+}
+
+// attemptsResumed saves the moved deadlines of running self-paced attempts, re-arms their
+// timers and sends each participant their new deadline. Attempts that had already run out are settled.
+func (qs *QuizService) attemptsResumed(quiz *models.Quiz, now time.Time) {
+  for userID, user := range quiz.GetParticipants() {
+    // Attempts that ran out before the pause end now
+    if qs.settleAttempt(quiz, user) || user.AttemptStartedAt == nil || user.AttemptComplete(now) {
+      continue
+    }
+
+    err := qs.RedisService.SaveUser(user)
+    if err != nil {
+      log.Printf("Warning: failed to save user to Redis: %v", err)
+    }
+    qs.armAttemptTimer(quiz, user)
+
+    qs.sendToUser(quiz.ID, userID, models.WebSocketMessage{
+      Type:    "attempt_question",
+      Payload: quiz.AttemptState(user),
+    })
+  }
+}
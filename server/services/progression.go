@@ -0,0 +1,119 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "encoding/json"
+  "fmt"
+  "log"
+)
+
+// NextQuestion closes the current question and shows the next one
+func (qs *QuizService) NextQuestion(quizID string) (*models.Question, error) {
+  return qs.moveToQuestion(quizID, 1)
+}
+
+// PreviousQuestion closes the current question and shows the previous one again.
+// Questions that were already closed stay closed, so going back is for review only.
+func (qs *QuizService) PreviousQuestion(quizID string) (*models.Question, error) {
+  return qs.moveToQuestion(quizID, -1)
+}
+
+// moveToQuestion moves the current-question pointer by step
+func (qs *QuizService) moveToQuestion(quizID string, step int) (*models.Question, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, err
+  }
+
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+  return qs.moveToQuestionLocked(quiz, step)
+}
+
+// moveToQuestionLocked moves the pointer; the caller holds progressMu so that host actions
+// and timers never advance the same question twice
+func (qs *QuizService) moveToQuestionLocked(quiz *models.Quiz, step int) (*models.Question, error) {
+  if quiz.Settings.IsSelfPaced() {
+    return nil, fmt.Errorf("participants move through self-paced quizzes on their own")
+  }
+
+  if err := checkOperation(quiz, ActionChangeQuestion); err != nil {
+    return nil, err
+  }
+
+  if quiz.InIntermission() {
+    return nil, fmt.Errorf("the round has ended; start the next round")
+  }
+
+  current := quiz.GetCurrentQuestion()
+  if current == nil {
+    return nil, fmt.Errorf("quiz has no current question")
+  }
+
+  index := quiz.CurrentQuestionIndex() + step
+  if index < 0 {
+    return nil, fmt.Errorf("already at the first question")
+  }
+  if index >= len(quiz.Questions) {
+    return nil, fmt.Errorf("already at the last question; end the quiz instead")
+  }
+
+  // Finishing a round stops for an intermission instead of showing the next question
+  if step > 0 && quiz.IsRoundBoundary(index) {
+    qs.endRound(quiz, current)
+    return nil, nil
+  }
+
+  qs.closeQuestion(quiz, current)
+  quiz.SetCurrentQuestion(index)
+  qs.openCurrentQuestion(quiz)
+
+  // Save to Redis
+  err := qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  qs.broadcastCurrentQuestion(quiz)
+
+  log.Printf("➡️  Quiz %s moved to question %d/%d", quiz.ID, index+1, len(quiz.Questions))
+  return quiz.GetCurrentQuestion(), nil
+}
+
+// broadcastCurrentQuestion sends the current question to the quiz's clients. Local participants
+// get their own option order; other instances receive the canonical order through Redis.
+func (qs *QuizService) broadcastCurrentQuestion(quiz *models.Quiz) {
+  participants := quiz.GetParticipants()
+
+  qs.Mu.RLock()
+  for client := range qs.Clients {
+    if client.QuizID != quiz.ID {
+      continue
+    }
+
+    data, err := json.Marshal(models.WebSocketMessage{
+      Type:    "current_question",
+      Payload: quiz.CurrentQuestionUpdate(participants[client.UserID]),
+    })
+    if err != nil {
+      log.Printf("Error marshaling message: %v", err)
+      continue
+    }
+
+    select {
+    case client.Send <- data:
+    default:
+      // Dead clients are removed by the next broadcastToQuiz
+    }
+  }
+  qs.Mu.RUnlock()
+
+  // Publish to Redis for cross-instance communication
+  err := qs.RedisService.PublishMessage("quiz:"+quiz.ID, models.WebSocketMessage{
+    Type:    "current_question",
+    Payload: quiz.CurrentQuestionUpdate(nil),
+  })
+  if err != nil {
+    log.Printf("Warning: failed to publish to Redis: %v", err)
+  }
+}
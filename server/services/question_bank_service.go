@@ -0,0 +1,214 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+  "math/rand"
+  "sort"
+  "sync"
+
+  "github.com/google/uuid"
+)
+
+// QuestionBankService manages reusable questions shared across quizzes
+type QuestionBankService struct {
+  Questions    map[string]*models.Question
+  RedisService *RedisService
+  mu           sync.RWMutex
+}
+
+// NewQuestionBankService creates a new question bank service
+func NewQuestionBankService(redisService *RedisService) *QuestionBankService {
+  return &QuestionBankService{
+    Questions:    make(map[string]*models.Question),
+    RedisService: redisService,
+  }
+}
+
+// CreateQuestion adds a new question to the bank
+func (qb *QuestionBankService) CreateQuestion(question models.Question) (*models.Question, error) {
+  if question.ID == "" {
+    question.ID = generateQuestionID()
+  }
+
+  if _, err := qb.GetQuestion(question.ID); err == nil {
+    return nil, fmt.Errorf("question already exists: %s", question.ID)
+  }
+
+  question.ApplyDefaults()
+  if err := question.Validate(); err != nil {
+    return nil, err
+  }
+
+  if err := qb.RedisService.SaveBankQuestion(&question); err != nil {
+    return nil, err
+  }
+
+  qb.mu.Lock()
+  qb.Questions[question.ID] = &question
+  qb.mu.Unlock()
+
+  log.Printf("📚 Added question %s to bank (%s)", question.ID, question.Category)
+  return &question, nil
+}
+
+// GetQuestion retrieves a bank question by ID
+func (qb *QuestionBankService) GetQuestion(questionID string) (*models.Question, error) {
+  if qb.RedisService.IsAvailable() {
+    return qb.RedisService.GetBankQuestion(questionID)
+  }
+
+  qb.mu.RLock()
+  defer qb.mu.RUnlock()
+  question, exists := qb.Questions[questionID]
+  if !exists {
+    return nil, fmt.Errorf("question not found: %s", questionID)
+  }
+  return question, nil
+}
+
+// UpdateQuestion replaces an existing bank question, re-indexing its category and tags
+func (qb *QuestionBankService) UpdateQuestion(questionID string, question models.Question) (*models.Question, error) {
+  existing, err := qb.GetQuestion(questionID)
+  if err != nil {
+    return nil, err
+  }
+
+  question.ID = questionID
+  question.ApplyDefaults()
+  if err := question.Validate(); err != nil {
+    return nil, err
+  }
+
+  // Overwrite in place, moving the question to its new category/tags atomically
+  if err := qb.RedisService.ReplaceBankQuestion(existing, &question); err != nil {
+    return nil, err
+  }
+
+  qb.mu.Lock()
+  qb.Questions[questionID] = &question
+  qb.mu.Unlock()
+
+  log.Printf("📚 Updated bank question %s", questionID)
+  return &question, nil
+}
+
+// DeleteQuestion removes a question from the bank
+func (qb *QuestionBankService) DeleteQuestion(questionID string) error {
+  existing, err := qb.GetQuestion(questionID)
+  if err != nil {
+    return err
+  }
+
+  if err := qb.RedisService.DeleteBankQuestion(existing); err != nil {
+    return err
+  }
+
+  qb.mu.Lock()
+  delete(qb.Questions, questionID)
+  qb.mu.Unlock()
+
+  log.Printf("🗑️  Deleted bank question %s", questionID)
+  return nil
+}
+
+// ListQuestions returns bank questions matching a category and all given tags
+func (qb *QuestionBankService) ListQuestions(category string, tags []string) ([]models.Question, error) {
+  questions := make([]models.Question, 0)
+
+  if qb.RedisService.IsAvailable() {
+    questionIDs, err := qb.RedisService.GetBankQuestionIDs(category, tags)
+    if err != nil {
+      return nil, err
+    }
+
+    for _, questionID := range questionIDs {
+      question, err := qb.RedisService.GetBankQuestion(questionID)
+      if err != nil {
+        log.Printf("Warning: failed to load bank question %s: %v", questionID, err)
+        continue
+      }
+      questions = append(questions, *question)
+    }
+  } else {
+    qb.mu.RLock()
+    for _, question := range qb.Questions {
+      if matchesBankFilter(question, category, tags) {
+        questions = append(questions, *question)
+      }
+    }
+    qb.mu.RUnlock()
+  }
+
+  sort.Slice(questions, func(i, j int) bool {
+    return questions[i].ID < questions[j].ID
+  })
+  return questions, nil
+}
+
+// DrawQuestions picks random questions from the bank for each draw
+func (qb *QuestionBankService) DrawQuestions(draws []models.BankDraw) ([]models.Question, error) {
+  drawn := make([]models.Question, 0)
+  used := make(map[string]bool)
+
+  for _, draw := range draws {
+    if draw.Count <= 0 {
+      return nil, fmt.Errorf("draw count for category %q must be positive", draw.Category)
+    }
+
+    candidates, err := qb.ListQuestions(draw.Category, draw.Tags)
+    if err != nil {
+      return nil, err
+    }
+
+    // Skip questions already drawn by an earlier, overlapping draw
+    available := make([]models.Question, 0, len(candidates))
+    for _, question := range candidates {
+      if !used[question.ID] {
+        available = append(available, question)
+      }
+    }
+
+    if len(available) < draw.Count {
+      return nil, fmt.Errorf("category %q has only %d matching questions, %d requested",
+        draw.Category, len(available), draw.Count)
+    }
+
+    rand.Shuffle(len(available), func(i, j int) {
+      available[i], available[j] = available[j], available[i]
+    })
+
+    for _, question := range available[:draw.Count] {
+      used[question.ID] = true
+      drawn = append(drawn, question)
+    }
+  }
+
+  return drawn, nil
+}
+
+// matchesBankFilter reports whether a question belongs to the category and carries all tags
+func matchesBankFilter(question *models.Question, category string, tags []string) bool {
+  if category != "" && question.Category != category {
+    return false
+  }
+
+  for _, tag := range tags {
+    found := false
+    for _, questionTag := range question.Tags {
+      if questionTag == tag {
+        found = true
+        break
+      }
+    }
+    if !found {
+      return false
+    }
+  }
+  return true
+}
+
+func generateQuestionID() string {
+  return uuid.New().String()[:8]
+}
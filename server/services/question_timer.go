@@ -0,0 +1,94 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "log"
+  "time"
+)
+
+// answerGracePeriod absorbs network latency for answers sent just before the deadline
+const answerGracePeriod = time.Second
+
+// openCurrentQuestion stamps the current question's open time and deadline and arms its timer.
+// The caller saves the quiz and broadcasts the question.
+func (qs *QuizService) openCurrentQuestion(quiz *models.Quiz) {
+  quiz.OpenCurrentQuestion(time.Now())
+  qs.armQuestionTimer(quiz)
+}
+
+// armQuestionTimer schedules the time-up handling for the current question, if it has a deadline
+func (qs *QuizService) armQuestionTimer(quiz *models.Quiz) {
+  qs.stopQuestionTimer(quiz.ID)
+
+  // Paused quizzes are re-armed on resume
+  question := quiz.GetCurrentQuestion()
+  deadline := quiz.GetQuestionDeadline()
+  if question == nil || deadline == nil || quiz.GetStatus() != models.QuizStatusActive {
+    return
+  }
+
+  quizID, questionID := quiz.ID, question.ID
+  timer := time.AfterFunc(time.Until(deadline.Add(answerGracePeriod)), func() {
+    qs.questionTimeUp(quizID, questionID)
+  })
+
+  qs.timersMu.Lock()
+  qs.timers[quizID] = timer
+  qs.timersMu.Unlock()
+}
+
+// stopQuestionTimer cancels the pending timer of a quiz, if any
+func (qs *QuizService) stopQuestionTimer(quizID string) {
+  qs.timersMu.Lock()
+  defer qs.timersMu.Unlock()
+  if timer, ok := qs.timers[quizID]; ok {
+    timer.Stop()
+    delete(qs.timers, quizID)
+  }
+}
+
+// questionTimeUp runs when a question's deadline (plus grace) has passed
+func (qs *QuizService) questionTimeUp(quizID, questionID string) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil || quiz.GetStatus() != models.QuizStatusActive || !quiz.IsCurrentQuestion(questionID) {
+    return
+  }
+
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type: "question_time_up",
+    Payload: map[string]interface{}{
+      "quiz_id":     quizID,
+      "question_id": questionID,
+    },
+  })
+
+  log.Printf("⏰ Time is up for question %s in quiz %s", questionID, quizID)
+
+  if quiz.AutoAdvances(questionID) {
+    qs.autoAdvance(quiz, questionID)
+  }
+}
+
+// autoAdvance closes questionID and moves on, ending the quiz after the last question
+// and stopping for an intermission at the end of a round.
+// It does nothing if the host has already moved away from questionID.
+func (qs *QuizService) autoAdvance(quiz *models.Quiz, questionID string) {
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+
+  // The host may have moved on or paused the quiz meanwhile
+  if quiz.GetStatus() != models.QuizStatusActive || !quiz.IsCurrentQuestion(questionID) {
+    return
+  }
+
+  if quiz.CurrentQuestionIndex() == len(quiz.Questions)-1 {
+    if err := qs.endQuizLocked(quiz); err != nil {
+      log.Printf("Warning: failed to end quiz %s: %v", quiz.ID, err)
+    }
+    return
+  }
+
+  if _, err := qs.moveToQuestionLocked(quiz, 1); err != nil {
+    log.Printf("Warning: failed to advance quiz %s: %v", quiz.ID, err)
+  }
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+)
+
+// CloneQuiz creates a fresh waiting quiz with the same title, questions and settings
+// as an existing quiz. Participants, answers and timing, including the self-paced open/close
+// window and any scheduled start, are not copied; title overrides the original title when set.
+func (qs *QuizService) CloneQuiz(quizID, title string) (*models.Quiz, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, err
+  }
+
+  if title == "" {
+    title = quiz.Title
+  }
+
+  return qs.CreateQuiz(models.CreateQuizRequest{
+    Title:     title,
+    Questions: models.CloneQuestions(quiz.Questions),
+    Rounds:    models.CloneRounds(quiz.Rounds),
+    Settings:  quiz.Settings.WithoutWindow(),
+  })
+}
+
+// SaveQuizAsTemplate stores the definition of an existing quiz as a reusable template
+func (qs *QuizService) SaveQuizAsTemplate(quizID, title string) (*models.QuizTemplate, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, err
+  }
+
+  if title == "" {
+    title = quiz.Title
+  }
+
+  return qs.Templates.CreateTemplate(title, quiz.Questions, quiz.Rounds, quiz.Settings)
+}
+
+// InstantiateTemplate creates a new waiting quiz from a template, without the open/close window
+// of the quiz it was saved from
+func (qs *QuizService) InstantiateTemplate(templateID, title string) (*models.Quiz, error) {
+  template, err := qs.Templates.GetTemplate(templateID)
+  if err != nil {
+    return nil, err
+  }
+
+  if title == "" {
+    title = template.Title
+  }
+
+  quiz, err := qs.CreateQuiz(models.CreateQuizRequest{
+    Title:     title,
+    Questions: models.CloneQuestions(template.Questions),
+    Rounds:    models.CloneRounds(template.Rounds),
+    Settings:  template.Settings.WithoutWindow(),
+  })
+  if err != nil {
+    return nil, fmt.Errorf("failed to instantiate template: %v", err)
+  }
+  return quiz, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+)
+
+// AddQuestion appends a question to a waiting quiz
+func (qs *QuizService) AddQuestion(quizID string, question models.Question) (*models.Question, error) {
+  if question.ID == "" {
+    question.ID = generateQuestionID()
+  }
+
+  quiz, err := qs.editQuestions(quizID, "question_added", func(questions []models.Question) ([]models.Question, error) {
+    return append(questions, question), nil
+  })
+  if err != nil {
+    return nil, err
+  }
+  return findQuestion(quiz, question.ID), nil
+}
+
+// UpdateQuestion replaces a question of a waiting quiz, keeping its position
+func (qs *QuizService) UpdateQuestion(quizID, questionID string, question models.Question) (*models.Question, error) {
+  question.ID = questionID
+
+  quiz, err := qs.editQuestions(quizID, "question_updated", func(questions []models.Question) ([]models.Question, error) {
+    for i := range questions {
+      if questions[i].ID == questionID {
+        questions[i] = question
+        return questions, nil
+      }
+    }
+    return nil, fmt.Errorf("question not found: %s", questionID)
+  })
+  if err != nil {
+    return nil, err
+  }
+  return findQuestion(quiz, questionID), nil
+}
+
+// DeleteQuestion removes a question from a waiting quiz
+func (qs *QuizService) DeleteQuestion(quizID, questionID string) error {
+  _, err := qs.editQuestions(quizID, "question_deleted", func(questions []models.Question) ([]models.Question, error) {
+    for i := range questions {
+      if questions[i].ID == questionID {
+        return append(questions[:i], questions[i+1:]...), nil
+      }
+    }
+    return nil, fmt.Errorf("question not found: %s", questionID)
+  })
+  return err
+}
+
+// ReorderQuestions sets the question order of a waiting quiz; questionIDs must list every question once
+func (qs *QuizService) ReorderQuestions(quizID string, questionIDs []string) error {
+  _, err := qs.editQuestions(quizID, "questions_reordered", func(questions []models.Question) ([]models.Question, error) {
+    if len(questionIDs) != len(questions) {
+      return nil, fmt.Errorf("expected %d question ids, got %d", len(questions), len(questionIDs))
+    }
+
+    byID := make(map[string]models.Question, len(questions))
+    for _, question := range questions {
+      byID[question.ID] = question
+    }
+
+    reordered := make([]models.Question, 0, len(questions))
+    for _, questionID := range questionIDs {
+      question, ok := byID[questionID]
+      if !ok {
+        return nil, fmt.Errorf("unknown or repeated question id: %s", questionID)
+      }
+      delete(byID, questionID)
+      reordered = append(reordered, question)
+    }
+    return reordered, nil
+  })
+  return err
+}
+
+// editQuestions applies an edit to a copy of a waiting quiz's questions, validates the result,
+// then stores it, persists the quiz and notifies connected clients
+func (qs *QuizService) editQuestions(quizID, action string, edit func([]models.Question) ([]models.Question, error)) (*models.Quiz, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, err
+  }
+
+  if err := checkOperation(quiz, ActionEditQuestions); err != nil {
+    return nil, err
+  }
+
+  questions, err := edit(append([]models.Question{}, quiz.Questions...))
+  if err != nil {
+    return nil, err
+  }
+
+  if err := models.ValidateQuestions(questions); err != nil {
+    return nil, fmt.Errorf("invalid questions: %v", err)
+  }
+
+  quiz.SetQuestions(questions)
+
+  // Shuffled orders were computed from the old question list
+  for _, user := range quiz.GetParticipants() {
+    user.AssignShuffle(questions, quiz.Settings)
+  }
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  // Broadcast quiz update; participants refetch their (possibly shuffled) view
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type: "quiz_updated",
+    Payload: map[string]interface{}{
+      "quiz_id":        quizID,
+      "action":         action,
+      "question_count": len(questions),
+    },
+  })
+
+  log.Printf("✏️  Quiz %s questions edited (%s)", quizID, action)
+  return quiz, nil
+}
@@ -3,9 +3,11 @@ package services
 import (
   "btaskee-quiz/models"
   "context"
+  "crypto/subtle"
   "encoding/json"
   "fmt"
   "log"
+  "os"
   "sync"
   "time"
 
@@ -17,7 +19,18 @@ type QuizService struct {
   Quizzes      map[string]*models.Quiz
   Clients      map[*Client]bool
   RedisService *RedisService
-  Mu           sync.RWMutex // Keep for Clients map only
+  QuestionBank *QuestionBankService
+  Templates    *TemplateService
+  AdminToken   string
+  Mu           sync.RWMutex // Guards the Quizzes and Clients maps; timers read Quizzes too
+
+  timers        map[string]*time.Timer // Current-question timers by quiz ID
+  attemptTimers map[string]*time.Timer // Self-paced attempt timers by quiz ID and user ID
+  timersMu      sync.Mutex
+  progressMu sync.Mutex // Serializes question changes from hosts and timers
+
+  schedules   map[string]*scheduledQuiz // Waiting quizzes with a scheduled start by quiz ID
+  schedulesMu sync.Mutex
 }
 
 // Client represents a WebSocket client
@@ -25,17 +38,14 @@ type Client struct {
   ID     string
   QuizID string
   UserID string
+  IsHost bool
   Send   chan []byte
   Hub    *QuizService
 }
 
 // NewQuizService creates a new quiz service
 func NewQuizService(redisService *RedisService) *QuizService {
-  qs := &QuizService{
-    Quizzes:      make(map[string]*models.Quiz),
-    Clients:      make(map[*Client]bool),
-    RedisService: redisService,
-  }
+  qs := NewStandaloneQuizService(redisService)
 
   // Load existing quizzes from Redis
   qs.loadQuizzesFromRedis()
@@ -43,29 +53,86 @@ func NewQuizService(redisService *RedisService) *QuizService {
   // Start Redis subscription for cross-instance communication
   go qs.startRedisSubscription()
 
+  // Start scheduled quizzes on time
+  go qs.runScheduler()
+
   return qs
 }
 
+// NewStandaloneQuizService creates a quiz service for one-shot commands such as the importer.
+// It does not load existing quizzes, re-arm their timers or run the scheduler and Redis
+// subscription, so it never drives quizzes a server is running.
+func NewStandaloneQuizService(redisService *RedisService) *QuizService {
+  return &QuizService{
+    Quizzes:      make(map[string]*models.Quiz),
+    Clients:      make(map[*Client]bool),
+    RedisService: redisService,
+    QuestionBank: NewQuestionBankService(redisService),
+    Templates:    NewTemplateService(redisService),
+    AdminToken:   os.Getenv("ADMIN_TOKEN"),
+    timers:        make(map[string]*time.Timer),
+    attemptTimers: make(map[string]*time.Timer),
+    schedules:     make(map[string]*scheduledQuiz),
+  }
+}
+
 // CreateQuiz creates a new quiz session
-func (qs *QuizService) CreateQuiz(title string) (*models.Quiz, error) {
+func (qs *QuizService) CreateQuiz(request models.CreateQuizRequest) (*models.Quiz, error) {
+  questions := request.Questions
+  if len(request.FromBank) > 0 {
+    drawn, err := qs.QuestionBank.DrawQuestions(request.FromBank)
+    if err != nil {
+      return nil, fmt.Errorf("failed to draw questions from bank: %v", err)
+    }
+    questions = append(append([]models.Question{}, questions...), drawn...)
+  }
+  if len(questions) == 0 && request.UseSampleQuestions {
+    questions = getSampleQuestions()
+  }
+
+  if err := models.ValidateQuestions(questions); err != nil {
+    return nil, fmt.Errorf("invalid questions: %v", err)
+  }
+
+  questions, err := models.ArrangeRounds(request.Rounds, questions)
+  if err != nil {
+    return nil, fmt.Errorf("invalid rounds: %v", err)
+  }
+
+  if err := request.Settings.Validate(); err != nil {
+    return nil, fmt.Errorf("invalid settings: %v", err)
+  }
+
+  if request.ScheduledStart != nil && !request.ScheduledStart.After(time.Now()) {
+    return nil, fmt.Errorf("scheduled start must be in the future")
+  }
+
+  title := request.Title
   quizID := generateQuizID()
   quiz := &models.Quiz{
-    ID:           quizID,
-    Title:        title,
-    Questions:    getSampleQuestions(),
-    Participants: make(map[string]*models.User),
-    Status:       models.QuizStatusWaiting,
-    CreatedAt:    time.Now(),
+    ID:             quizID,
+    Title:          title,
+    Questions:      questions,
+    Rounds:         request.Rounds,
+    Participants:   make(map[string]*models.User),
+    Status:         models.QuizStatusWaiting,
+    Settings:       request.Settings,
+    CreatedAt:      time.Now(),
+    ScheduledStart: request.ScheduledStart,
+    HostToken:      generateHostToken(),
   }
 
   // Save to Redis first
-  err := qs.RedisService.SaveQuiz(quiz)
+  err = qs.RedisService.SaveQuiz(quiz)
   if err != nil {
     return nil, fmt.Errorf("failed to save quiz to Redis: %v", err)
   }
 
   // Then add to memory
+  qs.Mu.Lock()
   qs.Quizzes[quizID] = quiz
+  qs.Mu.Unlock()
+  qs.scheduleQuiz(quiz)
 
   log.Printf("🎯 Created quiz: %s (%s)", title, quizID)
   return quiz, nil
@@ -74,7 +141,7 @@ func (qs *QuizService) CreateQuiz(title string) (*models.Quiz, error) {
 // GetQuiz retrieves a quiz by ID
 func (qs *QuizService) GetQuiz(quizID string) (*models.Quiz, error) {
   // Try memory first
-  if quiz, exists := qs.Quizzes[quizID]; exists {
+  if quiz, exists := qs.cachedQuiz(quizID); exists {
     return quiz, nil
   }
 
@@ -84,11 +151,38 @@ func (qs *QuizService) GetQuiz(quizID string) (*models.Quiz, error) {
     return nil, fmt.Errorf("quiz not found: %s", quizID)
   }
 
-  // Add to memory
+  // Add to memory, unless another request or timer loaded it meanwhile
+  qs.Mu.Lock()
+  if existing, exists := qs.Quizzes[quizID]; exists {
+    qs.Mu.Unlock()
+    return existing, nil
+  }
   qs.Quizzes[quizID] = quiz
+  qs.Mu.Unlock()
+
+  qs.migrateLeaderboard(quiz)
   return quiz, nil
 }
 
+// cachedQuiz returns a quiz held in memory
+func (qs *QuizService) cachedQuiz(quizID string) (*models.Quiz, bool) {
+  qs.Mu.RLock()
+  defer qs.Mu.RUnlock()
+  quiz, exists := qs.Quizzes[quizID]
+  return quiz, exists
+}
+
+// cachedQuizzes returns the quizzes held in memory
+func (qs *QuizService) cachedQuizzes() []*models.Quiz {
+  qs.Mu.RLock()
+  defer qs.Mu.RUnlock()
+  quizzes := make([]*models.Quiz, 0, len(qs.Quizzes))
+  for _, quiz := range qs.Quizzes {
+    quizzes = append(quizzes, quiz)
+  }
+  return quizzes
+}
+
 // JoinQuiz allows a user to join a quiz session
 func (qs *QuizService) JoinQuiz(quizID, userName string) (*models.User, error) {
   quiz, err := qs.GetQuiz(quizID)
@@ -96,6 +190,10 @@ func (qs *QuizService) JoinQuiz(quizID, userName string) (*models.User, error) {
     return nil, err
   }
 
+  if err := checkOperation(quiz, ActionJoin); err != nil {
+    return nil, err
+  }
+
   userID := generateUserID()
   user := &models.User{
     ID:       userID,
@@ -104,8 +202,11 @@ func (qs *QuizService) JoinQuiz(quizID, userName string) (*models.User, error) {
     Answers:  []models.Answer{},
     JoinedAt: time.Now(),
   }
+  user.AssignShuffle(quiz.Questions, quiz.Settings)
 
-  quiz.AddParticipant(user)
+  if err := quiz.Admit(user); err != nil {
+    return nil, err
+  }
 
   // Save to Redis
   err = qs.RedisService.SaveQuiz(quiz)
@@ -118,6 +219,13 @@ func (qs *QuizService) JoinQuiz(quizID, userName string) (*models.User, error) {
     log.Printf("Warning: failed to save user to Redis: %v", err)
   }
 
+  if qs.leaderboardInRedis(quiz) {
+    err = qs.RedisService.AddToLeaderboard(quizID, userID)
+    if err != nil {
+      log.Printf("Warning: %v", err)
+    }
+  }
+
   // Broadcast join event
   qs.broadcastToQuiz(quizID, models.WebSocketMessage{
     Type: "user_joined",
@@ -135,7 +243,7 @@ func (qs *QuizService) JoinQuiz(quizID, userName string) (*models.User, error) {
 }
 
 // SubmitAnswer processes a user's answer
-func (qs *QuizService) SubmitAnswer(quizID, userID, questionID string, answer int) error {
+func (qs *QuizService) SubmitAnswer(quizID, userID, questionID string, answer json.RawMessage) error {
   quiz, err := qs.GetQuiz(quizID)
   if err != nil {
     return err
@@ -152,36 +260,92 @@ func (qs *QuizService) SubmitAnswer(quizID, userID, questionID string, answer in
   }
 
   // Find the question
-  var question *models.Question
-  for _, q := range quiz.Questions {
-    if q.ID == questionID {
-      question = &q
-      break
+  question := findQuestion(quiz, questionID)
+  if question == nil {
+    return fmt.Errorf("question not found: %s", questionID)
+  }
+
+  if err := checkOperation(quiz, ActionAnswer); err != nil {
+    return err
+  }
+
+  // Late joiners admitted without points may catch up on questions closed before they joined
+  answeredAt := time.Now()
+  selfPaced := quiz.Settings.IsSelfPaced()
+  late := !selfPaced && quiz.IsQuestionClosed(questionID) && user.IsLateQuestion(questionID)
+  if selfPaced {
+    // Self-paced participants answer on their own clock, not the host's
+    qs.settleAttempt(quiz, user)
+    if err := checkAttemptAnswer(quiz, user, questionID, answeredAt); err != nil {
+      return err
+    }
+  } else if !late {
+    if quiz.IsQuestionClosed(questionID) {
+      return fmt.Errorf("question is closed: %s", questionID)
+    }
+
+    // Only the question the host is currently showing accepts answers
+    if !quiz.IsCurrentQuestion(questionID) {
+      return fmt.Errorf("question is not open: %s", questionID)
+    }
+
+    if quiz.IsPastDeadline(answeredAt, answerGracePeriod) {
+      return fmt.Errorf("time is up for question: %s", questionID)
     }
   }
 
-  if question == nil {
-    return fmt.Errorf("question not found: %s", questionID)
+  // Decode the answer payload for this question type
+  value, err := question.ParseAnswer(answer)
+  if err != nil {
+    return fmt.Errorf("invalid answer: %v", err)
   }
 
-  // Check if answer is correct
-  isCorrect := answer == question.Correct
+  // Map option indexes from the participant's shuffled order back to the canonical order
+  value = user.CanonicalAnswer(question, value)
+
+  // Time-decay scoring rewards fast answers, measured from when the user was shown the question
+  response := quiz.ResponseTime(question, user, answeredAt)
+
+  // Check if answer is correct and score it with the quiz's strategies
+  isCorrect := question.IsCorrect(value)
   points := 0
-  if isCorrect {
-    points = question.Points
+  var breakdown *models.ScoreBreakdown
+  if !late && !question.IsDeferred() {
+    scored := quiz.ScoreAnswer(models.ScoringInput{
+      Question: question,
+      Value:    value,
+      Correct:  isCorrect,
+      Response: response,
+      Streak:   user.CurrentStreak(),
+    })
+    breakdown, points = &scored, scored.Points
   }
 
   // Create answer record
   answerRecord := models.Answer{
     QuestionID: questionID,
-    Answer:     answer,
+    Answer:     value.Choice,
+    Choices:    value.Choices,
+    Text:       value.Text,
+    Number:     value.Number,
     Correct:    isCorrect,
     Points:     points,
-    AnsweredAt: time.Now(),
+    Breakdown:  breakdown,
+    AnsweredAt: answeredAt,
+  }
+  if response != nil {
+    responseMs := response.Milliseconds()
+    answerRecord.ResponseMs = &responseMs
+  }
+
+  // Closest-wins questions are scored once the question closes
+  if question.IsDeferred() && !late {
+    answerRecord.Pending = true
   }
 
   // Add answer to user
   user.AddAnswer(answerRecord)
+  qs.recordScore(quiz, user, answerRecord.Points)
 
   // Save to Redis
   err = qs.RedisService.SaveQuiz(quiz)
@@ -209,27 +373,134 @@ func (qs *QuizService) SubmitAnswer(quizID, userID, questionID string, answer in
 
   log.Printf("✅ User %s answered question %s (correct: %v, points: %d)",
     user.Name, questionID, isCorrect, points)
+
+  if selfPaced {
+    qs.advanceAttempt(quiz, user)
+    return nil
+  }
+
+  // Move on early once everyone has answered
+  if quiz.AutoAdvances(questionID) && quiz.AllAnswered(questionID) {
+    qs.autoAdvance(quiz, questionID)
+  }
   return nil
 }
 
-// GetLeaderboard returns the current leaderboard for a quiz
-func (qs *QuizService) GetLeaderboard(quizID string) ([]models.LeaderboardEntry, error) {
+// CloseQuestion stops accepting answers to a question and scores any deferred answers
+func (qs *QuizService) CloseQuestion(quizID, questionID string) error {
   quiz, err := qs.GetQuiz(quizID)
   if err != nil {
-    return nil, err
+    return err
   }
 
-  leaderboard := quiz.GetLeaderboard()
+  if err := checkOperation(quiz, ActionCloseQuestion); err != nil {
+    return err
+  }
 
-  // Save to Redis (async to avoid blocking)
-  go func() {
-    err := qs.RedisService.SaveLeaderboard(quizID, leaderboard)
+  // Closing reveals the answer, which participants still working through their attempt could use
+  if quiz.Settings.IsSelfPaced() {
+    return fmt.Errorf("questions of self-paced quizzes close when the quiz ends")
+  }
+
+  question := findQuestion(quiz, questionID)
+  if question == nil {
+    return fmt.Errorf("question not found: %s", questionID)
+  }
+
+  if !qs.closeQuestion(quiz, question) {
+    return fmt.Errorf("question already closed: %s", questionID)
+  }
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  return nil
+}
+
+// closeQuestion closes a question, scores deferred answers and notifies clients.
+// It returns false if the question was already closed; the caller saves the quiz.
+func (qs *QuizService) closeQuestion(quiz *models.Quiz, question *models.Question) bool {
+  if !quiz.CloseQuestion(question.ID) {
+    return false
+  }
+
+  if question.IsDeferred() {
+    qs.scoreDeferredQuestion(quiz, question)
+  }
+
+  // Negative marking may also cost the participants who did not answer
+  penalized := false
+  for _, user := range quiz.GetParticipants() {
+    if qs.penalizeSkip(quiz, question, user) {
+      penalized = true
+    }
+  }
+
+  // Broadcast question close
+  qs.broadcastToQuiz(quiz.ID, models.WebSocketMessage{
+    Type: "question_closed",
+    Payload: map[string]interface{}{
+      "quiz_id":     quiz.ID,
+      "question_id": question.ID,
+    },
+  })
+
+  qs.revealResults(quiz, question)
+
+  if question.IsDeferred() || penalized {
+    qs.broadcastLeaderboard(quiz.ID)
+  }
+
+  log.Printf("🔒 Question %s closed in quiz %s", question.ID, quiz.ID)
+  return true
+}
+
+// scoreDeferredQuestion resolves pending closest-wins answers once all answers are in
+func (qs *QuizService) scoreDeferredQuestion(quiz *models.Quiz, question *models.Question) {
+  numbers := make(map[string]float64)
+  for userID, user := range quiz.GetParticipants() {
+    answer, ok := user.GetAnswer(question.ID)
+    if ok && answer.Pending && answer.Number != nil {
+      numbers[userID] = *answer.Number
+    }
+  }
+
+  scores := question.ClosestScores(numbers)
+  for userID, points := range scores {
+    user := quiz.GetParticipants()[userID]
+    earned := quiz.RoundPoints(question.ID, points)
+    if !user.ResolveAnswer(question.ID, points == question.Points, earned) {
+      continue
+    }
+    qs.recordScore(quiz, user, earned)
+
+    err := qs.RedisService.SaveUser(user)
     if err != nil {
-      log.Printf("Warning: failed to save leaderboard to Redis: %v", err)
+      log.Printf("Warning: failed to save user to Redis: %v", err)
     }
-  }()
 
-  return leaderboard, nil
+    qs.broadcastToQuiz(quiz.ID, models.WebSocketMessage{
+      Type: "score_update",
+      Payload: models.UserScore{
+        UserID: userID,
+        Name:   user.Name,
+        Score:  user.GetScore(),
+      },
+    })
+  }
+}
+
+// GetLeaderboard returns the current leaderboard for a quiz
+func (qs *QuizService) GetLeaderboard(quizID string) ([]models.LeaderboardEntry, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, err
+  }
+
+  return quiz.GetLeaderboard(), nil
 }
 
 // StartQuiz starts a quiz session
@@ -239,13 +510,16 @@ func (qs *QuizService) StartQuiz(quizID string) error {
     return err
   }
 
-  if quiz.Status != models.QuizStatusWaiting {
-    return fmt.Errorf("quiz is not in waiting status")
+  if err := transition(quiz, ActionStart); err != nil {
+    return err
   }
 
+  qs.unscheduleQuiz(quizID)
+
   now := time.Now()
-  quiz.Status = models.QuizStatusActive
   quiz.StartedAt = &now
+  quiz.SetCurrentQuestion(0)
+  qs.openCurrentQuestion(quiz)
 
   // Save to Redis
   err = qs.RedisService.SaveQuiz(quiz)
@@ -262,6 +536,11 @@ func (qs *QuizService) StartQuiz(quizID string) error {
     },
   })
 
+  // Show the first question; self-paced participants get theirs when they start an attempt
+  if !quiz.Settings.IsSelfPaced() {
+    qs.broadcastCurrentQuestion(quiz)
+  }
+
   log.Printf("🚀 Quiz %s started", quizID)
   return nil
 }
@@ -273,12 +552,44 @@ func (qs *QuizService) EndQuiz(quizID string) error {
     return err
   }
 
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+  return qs.endQuizLocked(quiz)
+}
+
+// endQuizLocked ends the quiz; the caller holds progressMu so that a timer advancing the
+// quiz never closes or reveals the same question as the host ending it
+func (qs *QuizService) endQuizLocked(quiz *models.Quiz) error {
+  quizID := quiz.ID
+  current := quiz.GetCurrentQuestion()
+  if err := transition(quiz, ActionEnd); err != nil {
+    return err
+  }
+
+  qs.stopQuestionTimer(quizID)
+
   now := time.Now()
-  quiz.Status = models.QuizStatusEnded
+  if quiz.Settings.IsSelfPaced() {
+    qs.finishRunningAttempts(quiz, now)
+  }
+
+  // The question on screen closes as usual, with its results revealed
+  if current != nil {
+    qs.closeQuestion(quiz, current)
+  }
+
+  // Close remaining questions so deferred answers get scored
+  for i := range quiz.Questions {
+    question := &quiz.Questions[i]
+    if quiz.CloseQuestion(question.ID) && question.IsDeferred() {
+      qs.scoreDeferredQuestion(quiz, question)
+    }
+  }
+
   quiz.EndedAt = &now
 
   // Save to Redis
-  err = qs.RedisService.SaveQuiz(quiz)
+  err := qs.RedisService.SaveQuiz(quiz)
   if err != nil {
     log.Printf("Warning: failed to save quiz to Redis: %v", err)
   }
@@ -292,10 +603,113 @@ func (qs *QuizService) EndQuiz(quizID string) error {
     },
   })
 
+  // The last round ends with the quiz, unless the host ended it during an intermission
+  if quiz.HasRounds() && !quiz.InIntermission() && !quiz.Settings.IsSelfPaced() {
+    qs.broadcastRoundEnded(quiz)
+  }
+
+  // Broadcast final leaderboard
+  qs.broadcastLeaderboard(quizID)
+
   log.Printf("🏁 Quiz %s ended", quizID)
   return nil
 }
 
+// DeleteQuiz removes a quiz from memory and Redis, cancelling its scheduled start and timers
+func (qs *QuizService) DeleteQuiz(quizID string) error {
+  // Wait for a timer advancing the quiz to finish, so it cannot save the quiz back
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+
+  qs.unscheduleQuiz(quizID)
+  qs.stopQuestionTimer(quizID)
+  qs.stopAttemptTimers(quizID)
+
+  // Remove from memory
+  qs.Mu.Lock()
+  delete(qs.Quizzes, quizID)
+  qs.Mu.Unlock()
+
+  // Remove from Redis
+  return qs.RedisService.DeleteQuiz(quizID)
+}
+
+// ResetQuiz takes an ended quiz back to waiting so it can be run again. Participants stay
+// joined but their answers and scores are cleared.
+func (qs *QuizService) ResetQuiz(quizID string) error {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return err
+  }
+
+  if err := transition(quiz, ActionReset); err != nil {
+    return err
+  }
+
+  quiz.ResetProgress()
+
+  // Bring the quiz back from the archive
+  err = qs.RedisService.UnarchiveQuiz(quizID)
+  if err != nil {
+    log.Printf("Warning: %v", err)
+  }
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  userIDs := make([]string, 0, len(quiz.GetParticipants()))
+  for userID, user := range quiz.GetParticipants() {
+    err = qs.RedisService.SaveUser(user)
+    if err != nil {
+      log.Printf("Warning: failed to save user to Redis: %v", err)
+    }
+    userIDs = append(userIDs, userID)
+  }
+
+  if qs.leaderboardInRedis(quiz) {
+    err = qs.RedisService.ResetLeaderboard(quizID, userIDs)
+    if err != nil {
+      log.Printf("Warning: %v", err)
+    }
+  }
+
+  // Broadcast quiz reset
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type: "quiz_reset",
+    Payload: map[string]interface{}{
+      "quiz_id": quizID,
+    },
+  })
+
+  // Broadcast cleared leaderboard
+  qs.broadcastLeaderboard(quizID)
+
+  log.Printf("🔄 Quiz %s reset", quizID)
+  return nil
+}
+
+// IsHost checks whether a token grants host access to a quiz (its host token or the admin token)
+func (qs *QuizService) IsHost(quiz *models.Quiz, token string) bool {
+  if token == "" {
+    return false
+  }
+  if qs.IsAdmin(token) {
+    return true
+  }
+  return subtle.ConstantTimeCompare([]byte(token), []byte(quiz.HostToken)) == 1
+}
+
+// IsAdmin checks a token against ADMIN_TOKEN; nobody is an admin when it is not set
+func (qs *QuizService) IsAdmin(token string) bool {
+  if token == "" || qs.AdminToken == "" {
+    return false
+  }
+  return subtle.ConstantTimeCompare([]byte(token), []byte(qs.AdminToken)) == 1
+}
+
 // RegisterClient registers a WebSocket client
 func (qs *QuizService) RegisterClient(client *Client) {
   qs.Mu.Lock()
@@ -393,10 +807,21 @@ func (qs *QuizService) loadQuizzesFromRedis() {
       continue
     }
 
+    qs.Mu.Lock()
     qs.Quizzes[quizID] = quiz
+    qs.Mu.Unlock()
     log.Printf("📂 Loaded quiz %s from Redis", quizID)
   }
 
+  // Resume timers of running questions and pending schedules once every quiz is loaded;
+  // expired ones fire immediately. Leaderboards stored as JSON by older versions become sorted sets.
+  for _, quiz := range qs.cachedQuizzes() {
+    qs.migrateLeaderboard(quiz)
+    qs.armQuestionTimer(quiz)
+    qs.armAttemptTimers(quiz)
+    qs.scheduleQuiz(quiz)
+  }
+
   log.Printf("📂 Loaded %d quizzes from Redis", len(activeQuizzes))
 }
 
@@ -433,6 +858,15 @@ func (qs *QuizService) startRedisSubscription() {
 }
 
 // Helper functions
+func findQuestion(quiz *models.Quiz, questionID string) *models.Question {
+  for i := range quiz.Questions {
+    if quiz.Questions[i].ID == questionID {
+      return &quiz.Questions[i]
+    }
+  }
+  return nil
+}
+
 func generateQuizID() string {
   return uuid.New().String()[:8]
 }
@@ -441,6 +875,10 @@ func generateUserID() string {
   return uuid.New().String()[:8]
 }
 
+func generateHostToken() string {
+  return uuid.New().String()
+}
+
 // getSampleQuestions returns sample quiz questions
 func getSampleQuestions() []models.Question {
   return []models.Question{
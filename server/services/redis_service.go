@@ -6,6 +6,8 @@ import (
   "encoding/json"
   "fmt"
   "log"
+  "os"
+  "strconv"
   "time"
 
   "github.com/redis/go-redis/v9"
@@ -13,9 +15,13 @@ import (
 
 // RedisService handles all Redis operations
 type RedisService struct {
-  client *redis.Client
+  client           *redis.Client
+  archiveRetention time.Duration
 }
 
+// defaultArchiveRetention is how long ended quizzes are kept unless ARCHIVE_RETENTION says otherwise
+const defaultArchiveRetention = 30 * 24 * time.Hour
+
 // NewRedisService creates a new Redis service
 func NewRedisService() *RedisService {
   client := redis.NewClient(&redis.Options{
@@ -30,19 +36,41 @@ func NewRedisService() *RedisService {
   if err != nil {
     log.Printf("Redis connection failed: %v", err)
     log.Printf("Running in memory-only mode")
-    return &RedisService{client: nil}
+    return &RedisService{client: nil, archiveRetention: archiveRetentionFromEnv()}
   }
 
   log.Printf("Connected to Redis successfully")
-  return &RedisService{client: client}
+  return &RedisService{client: client, archiveRetention: archiveRetentionFromEnv()}
+}
+
+// archiveRetentionFromEnv reads ARCHIVE_RETENTION as a duration ("720h") or a number of days
+func archiveRetentionFromEnv() time.Duration {
+  value := os.Getenv("ARCHIVE_RETENTION")
+  if value == "" {
+    return defaultArchiveRetention
+  }
+
+  if retention, err := time.ParseDuration(value); err == nil && retention > 0 {
+    return retention
+  }
+  if days, err := strconv.Atoi(value); err == nil && days > 0 {
+    return time.Duration(days) * 24 * time.Hour
+  }
+
+  log.Printf("Warning: invalid ARCHIVE_RETENTION %q, using %v", value, defaultArchiveRetention)
+  return defaultArchiveRetention
 }
 
-// SaveQuiz saves a quiz to Redis
+// SaveQuiz saves a quiz to Redis. Ended quizzes are moved to the archive instead.
 func (rs *RedisService) SaveQuiz(quiz *models.Quiz) error {
   if rs.client == nil {
     return nil // Skip if Redis is not available
   }
 
+  if quiz.GetStatus() == models.QuizStatusEnded {
+    return rs.ArchiveQuiz(quiz)
+  }
+
   ctx := context.Background()
   quizData, err := json.Marshal(quiz)
   if err != nil {
@@ -74,6 +102,10 @@ func (rs *RedisService) GetQuiz(quizID string) (*models.Quiz, error) {
   ctx := context.Background()
   key := models.QuizKeyPrefix + quizID
   quizData, err := rs.client.Get(ctx, key).Result()
+  if err == redis.Nil {
+    // Ended quizzes live in the archive
+    quizData, err = rs.client.Get(ctx, models.ArchiveKeyPrefix+quizID).Result()
+  }
   if err != nil {
     if err == redis.Nil {
       return nil, fmt.Errorf("quiz not found: %s", quizID)
@@ -136,50 +168,465 @@ func (rs *RedisService) SaveUser(user *models.User) error {
 //  return &user, nil
 //}
 
-// SaveLeaderboard saves leaderboard to Redis
-func (rs *RedisService) SaveLeaderboard(quizID string, leaderboard []models.LeaderboardEntry) error {
+// IncrLeaderboardScore adds points (negative for penalties) to the user's score in the quiz's
+// leaderboard sorted set. The set is kept as long as an archived quiz.
+func (rs *RedisService) IncrLeaderboardScore(quizID, userID string, points int) error {
   if rs.client == nil {
     return nil
   }
 
   ctx := context.Background()
-  leaderboardData, err := json.Marshal(leaderboard)
+  key := models.LeaderboardKeyPrefix + quizID
+  pipe := rs.client.TxPipeline()
+  pipe.ZIncrBy(ctx, key, float64(points), userID)
+  pipe.Expire(ctx, key, rs.archiveRetention)
+  _, err := pipe.Exec(ctx)
   if err != nil {
-    return fmt.Errorf("failed to marshal leaderboard: %v", err)
+    return fmt.Errorf("failed to update leaderboard score in Redis: %v", err)
+  }
+
+  return nil
+}
+
+// AddToLeaderboard puts users on the quiz's leaderboard with no points, keeping any score
+// they already have
+func (rs *RedisService) AddToLeaderboard(quizID string, userIDs ...string) error {
+  if rs.client == nil || len(userIDs) == 0 {
+    return nil
+  }
+
+  members := make([]redis.Z, 0, len(userIDs))
+  for _, userID := range userIDs {
+    members = append(members, redis.Z{Score: 0, Member: userID})
   }
 
+  ctx := context.Background()
   key := models.LeaderboardKeyPrefix + quizID
-  err = rs.client.Set(ctx, key, leaderboardData, 24*time.Hour).Err()
+  pipe := rs.client.TxPipeline()
+  pipe.ZAddNX(ctx, key, members...)
+  pipe.Expire(ctx, key, rs.archiveRetention)
+  _, err := pipe.Exec(ctx)
   if err != nil {
-    return fmt.Errorf("failed to save leaderboard to Redis: %v", err)
+    return fmt.Errorf("failed to add to leaderboard in Redis: %v", err)
   }
 
   return nil
 }
 
-// GetLeaderboard retrieves leaderboard from Redis
-func (rs *RedisService) GetLeaderboard(quizID string) ([]models.LeaderboardEntry, error) {
+// ResetLeaderboard clears the quiz's leaderboard back to the given users with no points
+func (rs *RedisService) ResetLeaderboard(quizID string, userIDs []string) error {
   if rs.client == nil {
-    return nil, fmt.Errorf("Redis not available")
+    return nil
+  }
+
+  ctx := context.Background()
+  err := rs.client.Del(ctx, models.LeaderboardKeyPrefix+quizID).Err()
+  if err != nil {
+    return fmt.Errorf("failed to reset leaderboard in Redis: %v", err)
+  }
+
+  return rs.AddToLeaderboard(quizID, userIDs...)
+}
+
+// GetLeaderboardBlock returns the leaderboard entries from start to stop (highest score first)
+// together with every other entry sharing their scores, since Redis orders equal scores by
+// user ID rather than by the leaderboard's tie-breakers. It also returns the number of users on
+// the leaderboard and how many of them score higher than the block.
+func (rs *RedisService) GetLeaderboardBlock(quizID string, start, stop int64) ([]redis.Z, int64, int64, error) {
+  if rs.client == nil {
+    return nil, 0, 0, fmt.Errorf("Redis not available")
   }
 
   ctx := context.Background()
   key := models.LeaderboardKeyPrefix + quizID
-  leaderboardData, err := rs.client.Get(ctx, key).Result()
+  total, err := rs.client.ZCard(ctx, key).Result()
+  if err != nil {
+    return nil, 0, 0, fmt.Errorf("failed to count leaderboard: %v", err)
+  }
+
+  page, err := rs.client.ZRevRangeWithScores(ctx, key, start, stop).Result()
+  if err != nil {
+    return nil, 0, 0, fmt.Errorf("failed to get leaderboard from Redis: %v", err)
+  }
+  if len(page) == 0 {
+    return page, total, 0, nil
+  }
+
+  top := strconv.FormatFloat(page[0].Score, 'f', -1, 64)
+  bottom := strconv.FormatFloat(page[len(page)-1].Score, 'f', -1, 64)
+
+  pipe := rs.client.TxPipeline()
+  block := pipe.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{Max: top, Min: bottom})
+  higher := pipe.ZCount(ctx, key, "("+top, "+inf")
+  _, err = pipe.Exec(ctx)
+  if err != nil {
+    return nil, 0, 0, fmt.Errorf("failed to get leaderboard from Redis: %v", err)
+  }
+
+  return block.Val(), total, higher.Val(), nil
+}
+
+// MigrateLeaderboard seeds the quiz's leaderboard sorted set from the given scores unless it
+// already exists, replacing the JSON leaderboard older versions stored under the same key
+func (rs *RedisService) MigrateLeaderboard(quizID string, scores map[string]int) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  key := models.LeaderboardKeyPrefix + quizID
+  keyType, err := rs.client.Type(ctx, key).Result()
+  if err != nil {
+    return fmt.Errorf("failed to check leaderboard in Redis: %v", err)
+  }
+  if keyType == "zset" {
+    return nil
+  }
+
+  pipe := rs.client.TxPipeline()
+  pipe.Del(ctx, key)
+  if len(scores) > 0 {
+    members := make([]redis.Z, 0, len(scores))
+    for userID, score := range scores {
+      members = append(members, redis.Z{Score: float64(score), Member: userID})
+    }
+    pipe.ZAdd(ctx, key, members...)
+    pipe.Expire(ctx, key, rs.archiveRetention)
+  }
+  _, err = pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to migrate leaderboard in Redis: %v", err)
+  }
+
+  log.Printf("📊 Migrated leaderboard of quiz %s to a sorted set", quizID)
+  return nil
+}
+
+// GetLeaderboardRank returns the user's zero-based index on the quiz's leaderboard, highest
+// score first, and false if the user is not on it
+func (rs *RedisService) GetLeaderboardRank(quizID, userID string) (int64, bool, error) {
+  if rs.client == nil {
+    return 0, false, fmt.Errorf("Redis not available")
+  }
+
+  ctx := context.Background()
+  rank, err := rs.client.ZRevRank(ctx, models.LeaderboardKeyPrefix+quizID, userID).Result()
+  if err != nil {
+    if err == redis.Nil {
+      return 0, false, nil
+    }
+    return 0, false, fmt.Errorf("failed to get leaderboard rank from Redis: %v", err)
+  }
+
+  return rank, true, nil
+}
+
+// SaveBankQuestion saves a question bank entry and indexes it by category and tags
+func (rs *RedisService) SaveBankQuestion(question *models.Question) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  questionData, err := json.Marshal(question)
+  if err != nil {
+    return fmt.Errorf("failed to marshal question: %v", err)
+  }
+
+  pipe := rs.client.TxPipeline()
+  pipe.Set(ctx, models.QuestionKeyPrefix+question.ID, questionData, 0)
+  pipe.SAdd(ctx, models.QuestionBankKey, question.ID)
+  if question.Category != "" {
+    pipe.SAdd(ctx, models.QuestionCategoryKeyPrefix+question.Category, question.ID)
+  }
+  for _, tag := range question.Tags {
+    pipe.SAdd(ctx, models.QuestionTagKeyPrefix+tag, question.ID)
+  }
+
+  _, err = pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to save question to Redis: %v", err)
+  }
+
+  return nil
+}
+
+// GetBankQuestion retrieves a question bank entry from Redis
+func (rs *RedisService) GetBankQuestion(questionID string) (*models.Question, error) {
+  if rs.client == nil {
+    return nil, fmt.Errorf("Redis not available")
+  }
+
+  ctx := context.Background()
+  questionData, err := rs.client.Get(ctx, models.QuestionKeyPrefix+questionID).Result()
+  if err != nil {
+    if err == redis.Nil {
+      return nil, fmt.Errorf("question not found: %s", questionID)
+    }
+    return nil, fmt.Errorf("failed to get question from Redis: %v", err)
+  }
+
+  var question models.Question
+  err = json.Unmarshal([]byte(questionData), &question)
+  if err != nil {
+    return nil, fmt.Errorf("failed to unmarshal question: %v", err)
+  }
+
+  return &question, nil
+}
+
+// GetBankQuestionIDs returns the IDs of bank questions matching a category and all given tags.
+// An empty category and no tags returns every question in the bank.
+func (rs *RedisService) GetBankQuestionIDs(category string, tags []string) ([]string, error) {
+  if rs.client == nil {
+    return []string{}, nil
+  }
+
+  keys := []string{models.QuestionBankKey}
+  if category != "" {
+    keys = append(keys, models.QuestionCategoryKeyPrefix+category)
+  }
+  for _, tag := range tags {
+    keys = append(keys, models.QuestionTagKeyPrefix+tag)
+  }
+
+  ctx := context.Background()
+  questionIDs, err := rs.client.SInter(ctx, keys...).Result()
+  if err != nil {
+    return nil, fmt.Errorf("failed to get question ids: %v", err)
+  }
+
+  return questionIDs, nil
+}
+
+// ReplaceBankQuestion overwrites a question bank entry in place, moving it from the old
+// question's category and tags to the new ones in the same transaction
+func (rs *RedisService) ReplaceBankQuestion(old, question *models.Question) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  questionData, err := json.Marshal(question)
+  if err != nil {
+    return fmt.Errorf("failed to marshal question: %v", err)
+  }
+
+  pipe := rs.client.TxPipeline()
+  if old.Category != "" && old.Category != question.Category {
+    pipe.SRem(ctx, models.QuestionCategoryKeyPrefix+old.Category, question.ID)
+  }
+  for _, tag := range old.Tags {
+    if !containsString(question.Tags, tag) {
+      pipe.SRem(ctx, models.QuestionTagKeyPrefix+tag, question.ID)
+    }
+  }
+  pipe.Set(ctx, models.QuestionKeyPrefix+question.ID, questionData, 0)
+  pipe.SAdd(ctx, models.QuestionBankKey, question.ID)
+  if question.Category != "" {
+    pipe.SAdd(ctx, models.QuestionCategoryKeyPrefix+question.Category, question.ID)
+  }
+  for _, tag := range question.Tags {
+    pipe.SAdd(ctx, models.QuestionTagKeyPrefix+tag, question.ID)
+  }
+
+  _, err = pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to update question in Redis: %v", err)
+  }
+
+  return nil
+}
+
+func containsString(values []string, value string) bool {
+  for _, v := range values {
+    if v == value {
+      return true
+    }
+  }
+  return false
+}
+
+// DeleteBankQuestion removes a question bank entry and its index memberships
+func (rs *RedisService) DeleteBankQuestion(question *models.Question) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  pipe := rs.client.TxPipeline()
+  pipe.Del(ctx, models.QuestionKeyPrefix+question.ID)
+  pipe.SRem(ctx, models.QuestionBankKey, question.ID)
+  if question.Category != "" {
+    pipe.SRem(ctx, models.QuestionCategoryKeyPrefix+question.Category, question.ID)
+  }
+  for _, tag := range question.Tags {
+    pipe.SRem(ctx, models.QuestionTagKeyPrefix+tag, question.ID)
+  }
+
+  _, err := pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to delete question from Redis: %v", err)
+  }
+
+  return nil
+}
+
+// SaveTemplate saves a quiz template to Redis
+func (rs *RedisService) SaveTemplate(template *models.QuizTemplate) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  templateData, err := json.Marshal(template)
+  if err != nil {
+    return fmt.Errorf("failed to marshal template: %v", err)
+  }
+
+  pipe := rs.client.TxPipeline()
+  pipe.Set(ctx, models.TemplateKeyPrefix+template.ID, templateData, 0)
+  pipe.SAdd(ctx, models.TemplatesKey, template.ID)
+  _, err = pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to save template to Redis: %v", err)
+  }
+
+  return nil
+}
+
+// GetTemplate retrieves a quiz template from Redis
+func (rs *RedisService) GetTemplate(templateID string) (*models.QuizTemplate, error) {
+  if rs.client == nil {
+    return nil, fmt.Errorf("Redis not available")
+  }
+
+  ctx := context.Background()
+  templateData, err := rs.client.Get(ctx, models.TemplateKeyPrefix+templateID).Result()
   if err != nil {
     if err == redis.Nil {
-      return []models.LeaderboardEntry{}, nil
+      return nil, fmt.Errorf("template not found: %s", templateID)
     }
-    return nil, fmt.Errorf("failed to get leaderboard from Redis: %v", err)
+    return nil, fmt.Errorf("failed to get template from Redis: %v", err)
+  }
+
+  var template models.QuizTemplate
+  err = json.Unmarshal([]byte(templateData), &template)
+  if err != nil {
+    return nil, fmt.Errorf("failed to unmarshal template: %v", err)
+  }
+
+  return &template, nil
+}
+
+// GetTemplateIDs retrieves all quiz template IDs
+func (rs *RedisService) GetTemplateIDs() ([]string, error) {
+  if rs.client == nil {
+    return []string{}, nil
   }
 
-  var leaderboard []models.LeaderboardEntry
-  err = json.Unmarshal([]byte(leaderboardData), &leaderboard)
+  ctx := context.Background()
+  templateIDs, err := rs.client.SMembers(ctx, models.TemplatesKey).Result()
   if err != nil {
-    return nil, fmt.Errorf("failed to unmarshal leaderboard: %v", err)
+    return nil, fmt.Errorf("failed to get templates: %v", err)
   }
 
-  return leaderboard, nil
+  return templateIDs, nil
+}
+
+// DeleteTemplate removes a quiz template from Redis
+func (rs *RedisService) DeleteTemplate(templateID string) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  pipe := rs.client.TxPipeline()
+  pipe.Del(ctx, models.TemplateKeyPrefix+templateID)
+  pipe.SRem(ctx, models.TemplatesKey, templateID)
+  _, err := pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to delete template from Redis: %v", err)
+  }
+
+  return nil
+}
+
+// ArchiveQuiz moves an ended quiz out of the active set into the archive, which keeps it
+// for the archive retention and indexes it by end time
+func (rs *RedisService) ArchiveQuiz(quiz *models.Quiz) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  quizData, err := json.Marshal(quiz)
+  if err != nil {
+    return fmt.Errorf("failed to marshal quiz: %v", err)
+  }
+
+  endedAt := time.Now()
+  if quiz.EndedAt != nil {
+    endedAt = *quiz.EndedAt
+  }
+
+  pipe := rs.client.TxPipeline()
+  pipe.Set(ctx, models.ArchiveKeyPrefix+quiz.ID, quizData, rs.archiveRetention)
+  pipe.ZAdd(ctx, models.ArchivedQuizzesKey, redis.Z{Score: float64(endedAt.Unix()), Member: quiz.ID})
+  pipe.Del(ctx, models.QuizKeyPrefix+quiz.ID)
+  pipe.SRem(ctx, models.ActiveQuizzesKey, quiz.ID)
+  _, err = pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to archive quiz in Redis: %v", err)
+  }
+
+  log.Printf("🗄️  Archived quiz %s in Redis", quiz.ID)
+  return nil
+}
+
+// UnarchiveQuiz removes a quiz from the archive, e.g. after it is reset; SaveQuiz makes it active again
+func (rs *RedisService) UnarchiveQuiz(quizID string) error {
+  if rs.client == nil {
+    return nil
+  }
+
+  ctx := context.Background()
+  pipe := rs.client.TxPipeline()
+  pipe.Del(ctx, models.ArchiveKeyPrefix+quizID)
+  pipe.ZRem(ctx, models.ArchivedQuizzesKey, quizID)
+  _, err := pipe.Exec(ctx)
+  if err != nil {
+    return fmt.Errorf("failed to unarchive quiz in Redis: %v", err)
+  }
+
+  return nil
+}
+
+// GetArchivedQuizIDs returns archived quiz IDs, most recently ended first. Index entries
+// older than the retention period, whose quizzes have expired, are pruned first.
+func (rs *RedisService) GetArchivedQuizIDs(offset, limit int) ([]string, int64, error) {
+  if rs.client == nil {
+    return []string{}, 0, nil
+  }
+
+  ctx := context.Background()
+  cutoff := time.Now().Add(-rs.archiveRetention).Unix()
+  err := rs.client.ZRemRangeByScore(ctx, models.ArchivedQuizzesKey, "-inf", "("+strconv.FormatInt(cutoff, 10)).Err()
+  if err != nil {
+    log.Printf("Warning: failed to prune archived quizzes: %v", err)
+  }
+
+  total, err := rs.client.ZCard(ctx, models.ArchivedQuizzesKey).Result()
+  if err != nil {
+    return nil, 0, fmt.Errorf("failed to count archived quizzes: %v", err)
+  }
+
+  quizIDs, err := rs.client.ZRevRange(ctx, models.ArchivedQuizzesKey, int64(offset), int64(offset+limit-1)).Result()
+  if err != nil {
+    return nil, 0, fmt.Errorf("failed to get archived quizzes: %v", err)
+  }
+
+  return quizIDs, total, nil
 }
 
 // GetActiveQuizzes retrieves all active quiz IDs
@@ -225,6 +672,12 @@ func (rs *RedisService) DeleteQuiz(quizID string) error {
     log.Printf("Warning: failed to remove from active quizzes: %v", err)
   }
 
+  // Remove from the archive
+  err = rs.UnarchiveQuiz(quizID)
+  if err != nil {
+    log.Printf("Warning: %v", err)
+  }
+
   log.Printf("🗑️  Deleted quiz %s from Redis", quizID)
   return nil
 }
@@ -267,6 +720,22 @@ func (rs *RedisService) Close() error {
   return rs.client.Close()
 }
 
+// AcquireLock claims a key for ttl with SETNX so that only one instance performs an action.
+// Without Redis the single instance always gets the lock.
+func (rs *RedisService) AcquireLock(key string, ttl time.Duration) (bool, error) {
+  if rs.client == nil {
+    return true, nil
+  }
+
+  ctx := context.Background()
+  acquired, err := rs.client.SetNX(ctx, key, "1", ttl).Result()
+  if err != nil {
+    return false, fmt.Errorf("failed to acquire lock %s: %v", key, err)
+  }
+
+  return acquired, nil
+}
+
 // IsAvailable checks if Redis is available
 func (rs *RedisService) IsAvailable() bool {
   return rs.client != nil
@@ -0,0 +1,91 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "sort"
+)
+
+// EachResultRow calls fn with one row per participant per question, ordered by leaderboard
+// position and question order. Participants missing from the leaderboard, such as self-paced
+// ones who never finished an attempt, follow in join order without a position.
+// Rows are produced one at a time so callers can stream them.
+func (qs *QuizService) EachResultRow(quiz *models.Quiz, leaderboard []models.LeaderboardEntry, fn func(models.ResultRow) error) error {
+  participants := quiz.GetParticipants()
+
+  ranked := make(map[string]bool, len(leaderboard))
+  for _, entry := range leaderboard {
+    user, exists := participants[entry.UserID]
+    if !exists {
+      continue
+    }
+    ranked[user.ID] = true
+
+    position := entry.Position
+    if err := eachUserResultRow(quiz, user, &position, fn); err != nil {
+      return err
+    }
+  }
+
+  unranked := make([]*models.User, 0, len(participants)-len(ranked))
+  for userID, user := range participants {
+    if !ranked[userID] {
+      unranked = append(unranked, user)
+    }
+  }
+  sort.Slice(unranked, func(i, j int) bool {
+    if !unranked[i].JoinedAt.Equal(unranked[j].JoinedAt) {
+      return unranked[i].JoinedAt.Before(unranked[j].JoinedAt)
+    }
+    return unranked[i].ID < unranked[j].ID
+  })
+
+  for _, user := range unranked {
+    if err := eachUserResultRow(quiz, user, nil, fn); err != nil {
+      return err
+    }
+  }
+
+  return nil
+}
+
+// eachUserResultRow calls fn with the user's row for each question, in question order
+func eachUserResultRow(quiz *models.Quiz, user *models.User, position *int, fn func(models.ResultRow) error) error {
+  for i := range quiz.Questions {
+    question := &quiz.Questions[i]
+    row := models.ResultRow{
+      Position:     position,
+      UserID:       user.ID,
+      Name:         user.Name,
+      QuestionID:   question.ID,
+      QuestionText: question.Text,
+    }
+
+    // Skips only carry their penalty
+    if answer, ok := user.GetAnswer(question.ID); ok && answer.Skipped {
+      row.Points = answer.Points
+    } else if ok {
+      answeredAt := answer.AnsweredAt
+      row.Answered = true
+      row.Answer = question.DescribeAnswer(answer)
+      row.Correct = answer.Correct
+      row.Points = answer.Points
+      row.AnsweredAt = &answeredAt
+      row.ResponseMs = answer.ResponseMs
+
+      // Self-paced participants are timed from the start of their own attempt
+      startedAt := quiz.StartedAt
+      if quiz.Settings.IsSelfPaced() {
+        startedAt = user.AttemptStartedAt
+      }
+      if startedAt != nil {
+        timeTaken := answer.AnsweredAt.Sub(*startedAt).Milliseconds()
+        row.TimeTakenMs = &timeTaken
+      }
+    }
+
+    if err := fn(row); err != nil {
+      return err
+    }
+  }
+  return nil
+}
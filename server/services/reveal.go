@@ -0,0 +1,45 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "encoding/json"
+  "log"
+)
+
+// fastestShown is how many of the quickest correct responders a question reveal lists
+const fastestShown = 3
+
+// revealResults broadcasts the answer distribution of a closed question and tells each
+// locally connected participant how they did
+func (qs *QuizService) revealResults(quiz *models.Quiz, question *models.Question) {
+  qs.broadcastToQuiz(quiz.ID, models.WebSocketMessage{
+    Type:    "question_results",
+    Payload: quiz.QuestionResults(question, fastestShown),
+  })
+
+  participants := quiz.GetParticipants()
+
+  qs.Mu.RLock()
+  defer qs.Mu.RUnlock()
+  for client := range qs.Clients {
+    user, ok := participants[client.UserID]
+    if client.QuizID != quiz.ID || !ok {
+      continue
+    }
+
+    data, err := json.Marshal(models.WebSocketMessage{
+      Type:    "question_result",
+      Payload: quiz.PersonalResult(question, user),
+    })
+    if err != nil {
+      log.Printf("Error marshaling message: %v", err)
+      continue
+    }
+
+    select {
+    case client.Send <- data:
+    default:
+      // Dead clients are removed by the next broadcastToQuiz
+    }
+  }
+}
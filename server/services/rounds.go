@@ -0,0 +1,73 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+)
+
+// StartNextRound ends the intermission and shows the first question of the next round
+func (qs *QuizService) StartNextRound(quizID string) (*models.Question, error) {
+  quiz, err := qs.GetQuiz(quizID)
+  if err != nil {
+    return nil, err
+  }
+
+  qs.progressMu.Lock()
+  defer qs.progressMu.Unlock()
+
+  if err := checkOperation(quiz, ActionChangeQuestion); err != nil {
+    return nil, err
+  }
+
+  if !quiz.EndIntermission() {
+    return nil, fmt.Errorf("the current round has not ended")
+  }
+  qs.openCurrentQuestion(quiz)
+
+  // Save to Redis
+  err = qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  round := quiz.CurrentRound()
+  qs.broadcastToQuiz(quizID, models.WebSocketMessage{
+    Type: "round_started",
+    Payload: map[string]interface{}{
+      "quiz_id": quizID,
+      "round":   round,
+    },
+  })
+
+  qs.broadcastCurrentQuestion(quiz)
+
+  log.Printf("🎬 Quiz %s started round %d/%d", quizID, round.Index+1, round.RoundCount)
+  return quiz.GetCurrentQuestion(), nil
+}
+
+// endRound closes the round's last question and stops for an intermission; the caller holds progressMu
+func (qs *QuizService) endRound(quiz *models.Quiz, current *models.Question) {
+  qs.closeQuestion(quiz, current)
+  qs.stopQuestionTimer(quiz.ID)
+  quiz.StartIntermission()
+
+  // Save to Redis
+  err := qs.RedisService.SaveQuiz(quiz)
+  if err != nil {
+    log.Printf("Warning: failed to save quiz to Redis: %v", err)
+  }
+
+  qs.broadcastRoundEnded(quiz)
+}
+
+// broadcastRoundEnded sends the round-only and cumulative leaderboards of the current round
+func (qs *QuizService) broadcastRoundEnded(quiz *models.Quiz) {
+  results := quiz.RoundResults()
+  qs.broadcastToQuiz(quiz.ID, models.WebSocketMessage{
+    Type:    "round_ended",
+    Payload: results,
+  })
+
+  log.Printf("🏁 Quiz %s finished round %d/%d", quiz.ID, results.Round.Index+1, results.Round.RoundCount)
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+  "math"
+  "time"
+)
+
+// countdownMarks are the remaining seconds at which a scheduled quiz's lobby gets a countdown event
+var countdownMarks = map[int]bool{300: true, 60: true, 30: true, 10: true, 5: true, 4: true, 3: true, 2: true, 1: true}
+
+// scheduleLockTTL keeps schedule locks long enough that no other instance repeats the action
+const scheduleLockTTL = 10 * time.Minute
+
+// scheduledQuiz tracks a waiting quiz with a scheduled start
+type scheduledQuiz struct {
+  quiz          *models.Quiz
+  lastCountdown int
+}
+
+// scheduleQuiz registers a waiting quiz with a scheduled start with the scheduler
+func (qs *QuizService) scheduleQuiz(quiz *models.Quiz) {
+  if quiz.ScheduledStart == nil || quiz.GetStatus() != models.QuizStatusWaiting {
+    return
+  }
+
+  qs.schedulesMu.Lock()
+  qs.schedules[quiz.ID] = &scheduledQuiz{quiz: quiz}
+  qs.schedulesMu.Unlock()
+}
+
+// unscheduleQuiz removes a quiz from the scheduler, e.g. when the host starts it early
+func (qs *QuizService) unscheduleQuiz(quizID string) {
+  qs.schedulesMu.Lock()
+  delete(qs.schedules, quizID)
+  qs.schedulesMu.Unlock()
+}
+
+// runScheduler checks scheduled quizzes every second for the lifetime of the server
+func (qs *QuizService) runScheduler() {
+  ticker := time.NewTicker(time.Second)
+  defer ticker.Stop()
+
+  for now := range ticker.C {
+    qs.checkSchedules(now)
+  }
+}
+
+// checkSchedules sends countdown events and starts quizzes whose time has come
+func (qs *QuizService) checkSchedules(now time.Time) {
+  due := make([]*models.Quiz, 0)
+  countdowns := make(map[*models.Quiz]int)
+
+  qs.schedulesMu.Lock()
+  for _, entry := range qs.schedules {
+    remaining := int(math.Ceil(entry.quiz.ScheduledStart.Sub(now).Seconds()))
+    switch {
+    case remaining <= 0:
+      due = append(due, entry.quiz)
+    case countdownMarks[remaining] && remaining != entry.lastCountdown:
+      entry.lastCountdown = remaining
+      countdowns[entry.quiz] = remaining
+    }
+  }
+  qs.schedulesMu.Unlock()
+
+  for quiz, remaining := range countdowns {
+    if !qs.claimSchedule(quiz, fmt.Sprintf("countdown:%d", remaining)) {
+      continue
+    }
+
+    qs.broadcastToQuiz(quiz.ID, models.WebSocketMessage{
+      Type: "quiz_countdown",
+      Payload: map[string]interface{}{
+        "quiz_id":           quiz.ID,
+        "scheduled_start":   quiz.ScheduledStart,
+        "seconds_remaining": remaining,
+      },
+    })
+  }
+
+  for _, quiz := range due {
+    qs.unscheduleQuiz(quiz.ID)
+
+    if !qs.claimSchedule(quiz, "start") {
+      log.Printf("⏳ Scheduled quiz %s is started by another instance", quiz.ID)
+      continue
+    }
+
+    if err := qs.StartQuiz(quiz.ID); err != nil {
+      log.Printf("Warning: failed to start scheduled quiz %s: %v", quiz.ID, err)
+      continue
+    }
+    log.Printf("⏳ Scheduled quiz %s started", quiz.ID)
+  }
+}
+
+// claimSchedule reports whether this instance should perform a scheduled action for a quiz.
+// Locks are per scheduled start, so a quiz run again on a new schedule is not held back by the last one.
+func (qs *QuizService) claimSchedule(quiz *models.Quiz, action string) bool {
+  key := fmt.Sprintf("%s%s:%d:%s", models.ScheduleLockKeyPrefix, quiz.ID, quiz.ScheduledStart.Unix(), action)
+  acquired, err := qs.RedisService.AcquireLock(key, scheduleLockTTL)
+  if err != nil {
+    log.Printf("Warning: %v", err)
+    return false
+  }
+  return acquired
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "log"
+  "time"
+)
+
+// penalizeSkip records a penalized skip when the user left the question unanswered and the
+// quiz's negative marking counts skips. It returns whether a penalty was recorded.
+func (qs *QuizService) penalizeSkip(quiz *models.Quiz, question *models.Question, user *models.User) bool {
+  skip, ok := quiz.SkipAnswer(question, user, time.Now())
+  if !ok {
+    return false
+  }
+
+  user.AddAnswer(skip)
+  qs.recordScore(quiz, user, skip.Points)
+
+  err := qs.RedisService.SaveUser(user)
+  if err != nil {
+    log.Printf("Warning: failed to save user to Redis: %v", err)
+  }
+
+  qs.broadcastToQuiz(quiz.ID, models.WebSocketMessage{
+    Type: "score_update",
+    Payload: models.UserScore{
+      UserID: user.ID,
+      Name:   user.Name,
+      Score:  user.GetScore(),
+    },
+  })
+  return true
+}
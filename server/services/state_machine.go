@@ -0,0 +1,111 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+)
+
+// QuizAction is a lifecycle transition or an operation whose availability depends on the quiz status
+type QuizAction string
+
+const (
+  // Transitions
+  ActionStart  QuizAction = "start"
+  ActionPause  QuizAction = "pause"
+  ActionResume QuizAction = "resume"
+  ActionEnd    QuizAction = "end"
+  ActionReset  QuizAction = "reset"
+
+  // Operations
+  ActionJoin           QuizAction = "join"
+  ActionAnswer         QuizAction = "answer"
+  ActionEditQuestions  QuizAction = "edit_questions"
+  ActionChangeQuestion QuizAction = "change_question"
+  ActionCloseQuestion  QuizAction = "close_question"
+  ActionLockLobby      QuizAction = "lock_lobby"
+  ActionAttempt        QuizAction = "start_attempt"
+)
+
+// quizTransitions maps each status to the transitions it allows and their target status
+var quizTransitions = map[models.QuizStatus]map[QuizAction]models.QuizStatus{
+  models.QuizStatusWaiting: {
+    ActionStart: models.QuizStatusActive,
+  },
+  models.QuizStatusActive: {
+    ActionPause: models.QuizStatusPaused,
+    ActionEnd:   models.QuizStatusEnded,
+  },
+  models.QuizStatusPaused: {
+    ActionResume: models.QuizStatusActive,
+    ActionEnd:    models.QuizStatusEnded,
+  },
+  models.QuizStatusEnded: {
+    ActionReset: models.QuizStatusWaiting,
+  },
+}
+
+// quizOperations lists the operations each status permits
+var quizOperations = map[models.QuizStatus]map[QuizAction]bool{
+  models.QuizStatusWaiting: {
+    ActionJoin:          true,
+    ActionEditQuestions: true,
+    ActionLockLobby:     true,
+  },
+  models.QuizStatusActive: {
+    ActionJoin:           true,
+    ActionAnswer:         true,
+    ActionChangeQuestion: true,
+    ActionCloseQuestion:  true,
+    ActionLockLobby:      true,
+    ActionAttempt:        true,
+  },
+  models.QuizStatusPaused: {
+    ActionJoin:          true,
+    ActionCloseQuestion: true,
+    ActionLockLobby:     true,
+  },
+  models.QuizStatusEnded: {},
+}
+
+// QuizStateError is returned when an action is not allowed in the quiz's current status
+type QuizStateError struct {
+  QuizID string
+  Status models.QuizStatus
+  Action QuizAction
+}
+
+func (e *QuizStateError) Error() string {
+  return fmt.Sprintf("cannot %s quiz %s while it is %s", e.Action, e.QuizID, e.Status)
+}
+
+// CanPerform reports whether a status allows an action, either a transition or an operation
+func CanPerform(status models.QuizStatus, action QuizAction) bool {
+  if _, ok := quizTransitions[status][action]; ok {
+    return true
+  }
+  return quizOperations[status][action]
+}
+
+// checkOperation returns a QuizStateError if the quiz's status does not permit the operation
+func checkOperation(quiz *models.Quiz, action QuizAction) error {
+  status := quiz.GetStatus()
+  if !quizOperations[status][action] {
+    return &QuizStateError{QuizID: quiz.ID, Status: status, Action: action}
+  }
+  return nil
+}
+
+// transition atomically moves the quiz to the status the action leads to,
+// or returns a QuizStateError if the current status does not allow it
+func transition(quiz *models.Quiz, action QuizAction) error {
+  var err error
+  quiz.UpdateStatus(func(status models.QuizStatus) models.QuizStatus {
+    next, ok := quizTransitions[status][action]
+    if !ok {
+      err = &QuizStateError{QuizID: quiz.ID, Status: status, Action: action}
+      return status
+    }
+    return next
+  })
+  return err
+}
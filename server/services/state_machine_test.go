@@ -0,0 +1,154 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "errors"
+  "testing"
+)
+
+func TestTransition(t *testing.T) {
+  tests := []struct {
+    from    models.QuizStatus
+    action  QuizAction
+    want    models.QuizStatus
+    wantErr bool
+  }{
+    {models.QuizStatusWaiting, ActionStart, models.QuizStatusActive, false},
+    {models.QuizStatusWaiting, ActionPause, models.QuizStatusWaiting, true},
+    {models.QuizStatusWaiting, ActionResume, models.QuizStatusWaiting, true},
+    {models.QuizStatusWaiting, ActionEnd, models.QuizStatusWaiting, true},
+    {models.QuizStatusWaiting, ActionReset, models.QuizStatusWaiting, true},
+
+    {models.QuizStatusActive, ActionStart, models.QuizStatusActive, true},
+    {models.QuizStatusActive, ActionPause, models.QuizStatusPaused, false},
+    {models.QuizStatusActive, ActionResume, models.QuizStatusActive, true},
+    {models.QuizStatusActive, ActionEnd, models.QuizStatusEnded, false},
+    {models.QuizStatusActive, ActionReset, models.QuizStatusActive, true},
+
+    {models.QuizStatusPaused, ActionStart, models.QuizStatusPaused, true},
+    {models.QuizStatusPaused, ActionPause, models.QuizStatusPaused, true},
+    {models.QuizStatusPaused, ActionResume, models.QuizStatusActive, false},
+    {models.QuizStatusPaused, ActionEnd, models.QuizStatusEnded, false},
+    {models.QuizStatusPaused, ActionReset, models.QuizStatusPaused, true},
+
+    {models.QuizStatusEnded, ActionStart, models.QuizStatusEnded, true},
+    {models.QuizStatusEnded, ActionPause, models.QuizStatusEnded, true},
+    {models.QuizStatusEnded, ActionResume, models.QuizStatusEnded, true},
+    {models.QuizStatusEnded, ActionEnd, models.QuizStatusEnded, true},
+    {models.QuizStatusEnded, ActionReset, models.QuizStatusWaiting, false},
+
+    // Operations are not transitions
+    {models.QuizStatusActive, ActionAnswer, models.QuizStatusActive, true},
+  }
+
+  for _, tt := range tests {
+    t.Run(string(tt.from)+"/"+string(tt.action), func(t *testing.T) {
+      quiz := &models.Quiz{ID: "quiz1", Status: tt.from}
+      err := transition(quiz, tt.action)
+
+      if got := quiz.GetStatus(); got != tt.want {
+        t.Errorf("status = %s, want %s", got, tt.want)
+      }
+      if !tt.wantErr {
+        if err != nil {
+          t.Errorf("transition() error = %v, want nil", err)
+        }
+        return
+      }
+
+      var stateErr *QuizStateError
+      if !errors.As(err, &stateErr) {
+        t.Fatalf("transition() error = %v, want a *QuizStateError", err)
+      }
+      if stateErr.QuizID != "quiz1" || stateErr.Status != tt.from || stateErr.Action != tt.action {
+        t.Errorf("QuizStateError = %+v, want quiz1, %s, %s", stateErr, tt.from, tt.action)
+      }
+    })
+  }
+}
+
+func TestCheckOperation(t *testing.T) {
+  tests := []struct {
+    status  models.QuizStatus
+    action  QuizAction
+    allowed bool
+  }{
+    {models.QuizStatusWaiting, ActionJoin, true},
+    {models.QuizStatusWaiting, ActionEditQuestions, true},
+    {models.QuizStatusWaiting, ActionLockLobby, true},
+    {models.QuizStatusWaiting, ActionAnswer, false},
+    {models.QuizStatusWaiting, ActionChangeQuestion, false},
+    {models.QuizStatusWaiting, ActionAttempt, false},
+
+    {models.QuizStatusActive, ActionJoin, true},
+    {models.QuizStatusActive, ActionAnswer, true},
+    {models.QuizStatusActive, ActionChangeQuestion, true},
+    {models.QuizStatusActive, ActionCloseQuestion, true},
+    {models.QuizStatusActive, ActionAttempt, true},
+    {models.QuizStatusActive, ActionEditQuestions, false},
+
+    {models.QuizStatusPaused, ActionJoin, true},
+    {models.QuizStatusPaused, ActionCloseQuestion, true},
+    {models.QuizStatusPaused, ActionAnswer, false},
+    {models.QuizStatusPaused, ActionChangeQuestion, false},
+    {models.QuizStatusPaused, ActionAttempt, false},
+
+    {models.QuizStatusEnded, ActionJoin, false},
+    {models.QuizStatusEnded, ActionAnswer, false},
+    {models.QuizStatusEnded, ActionLockLobby, false},
+
+    // Transitions are not operations
+    {models.QuizStatusWaiting, ActionStart, false},
+  }
+
+  for _, tt := range tests {
+    t.Run(string(tt.status)+"/"+string(tt.action), func(t *testing.T) {
+      err := checkOperation(&models.Quiz{ID: "quiz1", Status: tt.status}, tt.action)
+      if tt.allowed {
+        if err != nil {
+          t.Errorf("checkOperation() error = %v, want nil", err)
+        }
+        return
+      }
+
+      var stateErr *QuizStateError
+      if !errors.As(err, &stateErr) {
+        t.Fatalf("checkOperation() error = %v, want a *QuizStateError", err)
+      }
+      if stateErr.Status != tt.status || stateErr.Action != tt.action {
+        t.Errorf("QuizStateError = %+v, want %s, %s", stateErr, tt.status, tt.action)
+      }
+    })
+  }
+}
+
+func TestCanPerform(t *testing.T) {
+  tests := []struct {
+    status models.QuizStatus
+    action QuizAction
+    want   bool
+  }{
+    {models.QuizStatusWaiting, ActionStart, true},
+    {models.QuizStatusWaiting, ActionJoin, true},
+    {models.QuizStatusWaiting, ActionEnd, false},
+    {models.QuizStatusActive, ActionPause, true},
+    {models.QuizStatusActive, ActionAnswer, true},
+    {models.QuizStatusEnded, ActionReset, true},
+    {models.QuizStatusEnded, ActionJoin, false},
+    {"unknown", ActionStart, false},
+  }
+
+  for _, tt := range tests {
+    if got := CanPerform(tt.status, tt.action); got != tt.want {
+      t.Errorf("CanPerform(%s, %s) = %v, want %v", tt.status, tt.action, got, tt.want)
+    }
+  }
+}
+
+func TestQuizStateErrorMessage(t *testing.T) {
+  err := &QuizStateError{QuizID: "quiz1", Status: models.QuizStatusEnded, Action: ActionStart}
+  want := "cannot start quiz quiz1 while it is ended"
+  if got := err.Error(); got != want {
+    t.Errorf("Error() = %q, want %q", got, want)
+  }
+}
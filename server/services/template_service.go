@@ -0,0 +1,134 @@
+package services
+
+import (
+  "btaskee-quiz/models"
+  "fmt"
+  "log"
+  "sort"
+  "sync"
+  "time"
+
+  "github.com/google/uuid"
+)
+
+// TemplateService stores quiz definitions that can be instantiated into new quizzes
+type TemplateService struct {
+  Templates    map[string]*models.QuizTemplate
+  RedisService *RedisService
+  mu           sync.RWMutex
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService(redisService *RedisService) *TemplateService {
+  return &TemplateService{
+    Templates:    make(map[string]*models.QuizTemplate),
+    RedisService: redisService,
+  }
+}
+
+// CreateTemplate validates and stores a new template
+func (ts *TemplateService) CreateTemplate(title string, questions []models.Question, rounds []models.Round, settings models.QuizSettings) (*models.QuizTemplate, error) {
+  if title == "" {
+    return nil, fmt.Errorf("title is required")
+  }
+
+  questions = models.CloneQuestions(questions)
+  if err := models.ValidateQuestions(questions); err != nil {
+    return nil, fmt.Errorf("invalid questions: %v", err)
+  }
+
+  rounds = models.CloneRounds(rounds)
+  questions, err := models.ArrangeRounds(rounds, questions)
+  if err != nil {
+    return nil, fmt.Errorf("invalid rounds: %v", err)
+  }
+
+  template := &models.QuizTemplate{
+    ID:        generateTemplateID(),
+    Title:     title,
+    Questions: questions,
+    Rounds:    rounds,
+    Settings:  settings,
+    CreatedAt: time.Now(),
+  }
+
+  if err = ts.RedisService.SaveTemplate(template); err != nil {
+    return nil, err
+  }
+
+  ts.mu.Lock()
+  ts.Templates[template.ID] = template
+  ts.mu.Unlock()
+
+  log.Printf("📋 Saved template: %s (%s)", title, template.ID)
+  return template, nil
+}
+
+// GetTemplate retrieves a template by ID
+func (ts *TemplateService) GetTemplate(templateID string) (*models.QuizTemplate, error) {
+  if ts.RedisService.IsAvailable() {
+    return ts.RedisService.GetTemplate(templateID)
+  }
+
+  ts.mu.RLock()
+  defer ts.mu.RUnlock()
+  template, exists := ts.Templates[templateID]
+  if !exists {
+    return nil, fmt.Errorf("template not found: %s", templateID)
+  }
+  return template, nil
+}
+
+// ListTemplates returns all templates, newest first
+func (ts *TemplateService) ListTemplates() ([]*models.QuizTemplate, error) {
+  templates := make([]*models.QuizTemplate, 0)
+
+  if ts.RedisService.IsAvailable() {
+    templateIDs, err := ts.RedisService.GetTemplateIDs()
+    if err != nil {
+      return nil, err
+    }
+
+    for _, templateID := range templateIDs {
+      template, err := ts.RedisService.GetTemplate(templateID)
+      if err != nil {
+        log.Printf("Warning: failed to load template %s: %v", templateID, err)
+        continue
+      }
+      templates = append(templates, template)
+    }
+  } else {
+    ts.mu.RLock()
+    for _, template := range ts.Templates {
+      templates = append(templates, template)
+    }
+    ts.mu.RUnlock()
+  }
+
+  sort.Slice(templates, func(i, j int) bool {
+    return templates[i].CreatedAt.After(templates[j].CreatedAt)
+  })
+  return templates, nil
+}
+
+// DeleteTemplate removes a template; quizzes created from it are unaffected
+func (ts *TemplateService) DeleteTemplate(templateID string) error {
+  if _, err := ts.GetTemplate(templateID); err != nil {
+    return err
+  }
+
+  if err := ts.RedisService.DeleteTemplate(templateID); err != nil {
+    return err
+  }
+
+  ts.mu.Lock()
+  delete(ts.Templates, templateID)
+  ts.mu.Unlock()
+
+  log.Printf("🗑️  Deleted template %s", templateID)
+  return nil
+}
+
+func generateTemplateID() string {
+  return uuid.New().String()[:8]
+}